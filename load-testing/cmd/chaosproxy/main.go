@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"load-test/internal/chaos"
+	"load-test/internal/config"
+)
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	listenFlag := flag.String("listen", ":8081", "Address to listen on")
+	targetFlag := flag.String("target", cfg.API.FullURL, "API to proxy to")
+	basePathFlag := flag.String("base-path", cfg.API.BasePath, "Incoming prefix mapped onto -target (other paths go to the target's origin)")
+	scheduleFlag := flag.String("schedule", "", "JSON fault schedule (overrides the single-phase fault flags)")
+	recordFlag := flag.String("record", filepath.Join(cfg.Output.ResultsDir, "chaos_faults.json"), "File to record injected faults to")
+	seedFlag := flag.Int64("seed", time.Now().UnixNano(), "Random seed for fault probabilities and jitter")
+
+	routeFlag := flag.String("route", "*", "Route pattern for the single-phase faults (e.g. /movies/*, /movies/:id)")
+	latencyFlag := flag.Duration("latency", 0, "Added latency")
+	jitterFlag := flag.Duration("jitter", 0, "Random +/- jitter on the added latency")
+	errorRateFlag := flag.Float64("error-rate", 0, "%% of requests answered with -error-status")
+	errorStatusFlag := flag.Int("error-status", 503, "Status code for injected errors")
+	resetRateFlag := flag.Float64("reset-rate", 0, "%% of connections reset before a response")
+	bandwidthFlag := flag.Int("bandwidth", 0, "Response bandwidth limit in bytes/sec")
+	slowBodyFlag := flag.Duration("slow-body", 0, "Delay between 256-byte response body chunks")
+	config.FileFlags(flag.CommandLine)
+	flag.Parse()
+
+	if err := cfg.Validate("api", "output"); err != nil {
+		log.Fatal(err)
+	}
+
+	var schedule chaos.Schedule
+	if *scheduleFlag != "" {
+		if schedule, err = chaos.LoadSchedule(*scheduleFlag); err != nil {
+			log.Fatalf("Failed to load schedule: %v", err)
+		}
+	} else {
+		phase := chaos.Phase{Name: "constant"}
+		add := func(f chaos.Fault) {
+			f.Route = *routeFlag
+			if f.Probability == 0 {
+				f.Probability = 100
+			}
+			if err := f.Validate(); err != nil {
+				log.Fatalf("Invalid fault flags: %v", err)
+			}
+			phase.Faults = append(phase.Faults, f)
+		}
+		if *latencyFlag > 0 || *jitterFlag > 0 {
+			add(chaos.Fault{Type: chaos.FaultLatency, Latency: *latencyFlag, Jitter: *jitterFlag})
+		}
+		if *errorRateFlag > 0 {
+			add(chaos.Fault{Type: chaos.FaultError, Probability: *errorRateFlag, Status: *errorStatusFlag})
+		}
+		if *resetRateFlag > 0 {
+			add(chaos.Fault{Type: chaos.FaultReset, Probability: *resetRateFlag})
+		}
+		if *bandwidthFlag > 0 {
+			add(chaos.Fault{Type: chaos.FaultBandwidth, Bandwidth: *bandwidthFlag})
+		}
+		if *slowBodyFlag > 0 {
+			add(chaos.Fault{Type: chaos.FaultSlowBody, Latency: *slowBodyFlag})
+		}
+		schedule.Phases = []chaos.Phase{phase}
+	}
+
+	fmt.Printf("\n🌪️  Starting Chaos Proxy\n")
+	fmt.Printf("═══════════════════════════════════════════════════════\n")
+	fmt.Printf("  Listen: %s → %s\n", *listenFlag, *targetFlag)
+	fmt.Printf("  Recording to: %s\n", *recordFlag)
+	for _, phase := range schedule.Phases {
+		duration := "until stopped"
+		if phase.Duration > 0 {
+			duration = phase.Duration.String()
+		}
+		fmt.Printf("  Phase %s (%s):\n", phase.Name, duration)
+		if len(phase.Faults) == 0 {
+			fmt.Printf("     no faults\n")
+		}
+		for _, f := range phase.Faults {
+			fmt.Printf("     %s\n", describeFault(f))
+		}
+	}
+	if schedule.Loop {
+		fmt.Printf("  Schedule loops\n")
+	}
+	fmt.Printf("═══════════════════════════════════════════════════════\n\n")
+
+	if err := os.MkdirAll(filepath.Dir(*recordFlag), 0755); err != nil {
+		log.Fatalf("Failed to create record directory: %v", err)
+	}
+
+	recorder := chaos.NewRecorder(*targetFlag)
+	proxy, err := chaos.NewProxy(*targetFlag, *basePathFlag, schedule, recorder, *seedFlag)
+	if err != nil {
+		log.Fatalf("Failed to create proxy: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				proxy.Tick(now)
+				if now.Second()%5 == 0 {
+					if err := recorder.Save(*recordFlag); err != nil {
+						log.Printf("Warning: failed to save fault record: %v", err)
+					}
+				}
+			}
+		}
+	}()
+
+	httpServer := &http.Server{Addr: *listenFlag, Handler: proxy}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Printf("✅ Proxying on %s — press Ctrl+C to stop\n", *listenFlag)
+	if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatalf("Chaos proxy failed: %v", err)
+	}
+
+	if err := recorder.Save(*recordFlag); err != nil {
+		log.Fatalf("Failed to save fault record: %v", err)
+	}
+
+	faultLog := recorder.Snapshot()
+	fmt.Printf("\n\n📊 Injected Faults\n")
+	fmt.Printf("═══════════════════════════════════════════════════════\n")
+	for _, phase := range faultLog.Phases {
+		fmt.Printf("  %s %s–%s: %d requests\n", phase.Name, phase.Start.Format("15:04:05"), phase.End.Format("15:04:05"), phase.Requests)
+		for _, injected := range phase.Injected {
+			fmt.Printf("     %-10s %-24s %d\n", injected.Type, injected.Route, injected.Count)
+		}
+	}
+	fmt.Printf("═══════════════════════════════════════════════════════\n")
+	fmt.Printf("\n✅ Fault record saved to: %s\n", *recordFlag)
+	fmt.Printf("\n💡 Overlay on a report: go run ./cmd/report -faults %s\n\n", *recordFlag)
+}
+
+func describeFault(f chaos.Fault) string {
+	var detail string
+	switch f.Type {
+	case chaos.FaultLatency:
+		detail = fmt.Sprintf("+%s ± %s", f.Latency, f.Jitter)
+	case chaos.FaultSlowBody:
+		detail = fmt.Sprintf("%s per %d-byte chunk", f.Latency, 256)
+	case chaos.FaultBandwidth:
+		detail = fmt.Sprintf("%d bytes/sec", f.Bandwidth)
+	case chaos.FaultError:
+		detail = fmt.Sprintf("HTTP %d", f.Status)
+	}
+	return fmt.Sprintf("%-10s %-20s %5.1f%% %s", f.Type, f.Route, f.Probability, detail)
+}
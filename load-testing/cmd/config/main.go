@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"load-test/internal/config"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	command := os.Args[1]
+	switch command {
+	case "print", "validate":
+	case "profiles":
+		for _, name := range config.Profiles() {
+			fmt.Println(name)
+		}
+		return
+	case "datasets":
+		for _, name := range config.Datasets() {
+			fmt.Println(name)
+		}
+		return
+	default:
+		usage()
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fs := flag.NewFlagSet(command, flag.ExitOnError)
+	config.FileFlags(fs)
+	config.DatasetFlag(fs)
+	for _, key := range config.Keys() {
+		cfg.BindFlag(fs, key, key)
+	}
+	fs.Parse(os.Args[2:])
+
+	if command == "print" {
+		cfg.Print(os.Stdout)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		log.Fatal(err)
+	}
+	if command == "validate" {
+		fmt.Println("✅ Configuration is valid")
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: config <command> [-config file] [-profile name] [-dataset name] [-<key> value ...]\n\n")
+	fmt.Fprintf(os.Stderr, "Commands:\n")
+	fmt.Fprintf(os.Stderr, "  print      Show the effective config (defaults < file < env < flags) with secrets masked\n")
+	fmt.Fprintf(os.Stderr, "  validate   Check the effective config and exit non-zero on problems\n")
+	fmt.Fprintf(os.Stderr, "  profiles   List the built-in profiles\n")
+	fmt.Fprintf(os.Stderr, "  datasets   List the built-in generator dataset presets\n")
+}
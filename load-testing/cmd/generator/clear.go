@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"load-test/internal/config"
+	"load-test/internal/generator"
+)
+
+type clearOptions struct {
+	mode       string
+	generation string
+	force      bool
+	yes        bool
+}
+
+func runClear(args []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	fs := flag.NewFlagSet("clear", flag.ExitOnError)
+	config.FileFlags(fs)
+	bindMongoFlags(cfg, fs)
+	cfg.BindFlag(fs, "clear-mode", "generator.clearMode")
+	generationFlag := fs.String("generation", "", "With -clear-mode generated: only delete this generation (default: every generated document)")
+	forceFlag := fs.Bool("force", false, "Allow dropping collections on a non-local MongoDB host")
+	yesFlag := fs.Bool("yes", false, "Skip the interactive confirmation before clearing")
+	fs.Parse(args)
+
+	if err := cfg.Validate("mongodb", "generator.clearMode"); err != nil {
+		log.Fatal(err)
+	}
+	if *generationFlag != "" && cfg.Generator.ClearMode != config.ClearGenerated {
+		log.Fatalf("clear: -generation requires -clear-mode %s", config.ClearGenerated)
+	}
+
+	ctx := context.Background()
+	db, disconnect := connect(ctx, cfg.MongoDB)
+	defer disconnect()
+
+	clearOpts := clearOptions{mode: cfg.Generator.ClearMode, generation: *generationFlag, force: *forceFlag, yes: *yesFlag}
+	if err := clearData(ctx, db, cfg.MongoDB, clearOpts); err != nil {
+		log.Fatalf("Failed to clear data: %v", err)
+	}
+}
+
+func clearData(ctx context.Context, db *mongo.Database, mongoCfg config.MongoDBConfig, opts clearOptions) error {
+	names := mongoCfg.CollectionNames().All()
+	local := mongoCfg.IsLocal()
+
+	if opts.mode == config.ClearDrop && !local && !opts.force {
+		return fmt.Errorf("refusing to drop collections on non-local host %s; pass -force, use -clear-mode generated, or -clear=false",
+			strings.Join(mongoCfg.Hosts(), ","))
+	}
+
+	counts, err := generator.CountDocuments(ctx, db, names, opts.generation)
+	if err != nil {
+		return fmt.Errorf("failed to count documents: %w", err)
+	}
+
+	var affected int64
+	fmt.Printf("\n🗑️  Clearing %s on %s (%s)\n", db.Name(), strings.Join(mongoCfg.Hosts(), ","), describeClear(opts))
+	for _, count := range counts {
+		fmt.Printf("   %-24s %10d documents (%d generated)\n", count.Name, count.Total, count.Generated)
+		if opts.mode == config.ClearDrop {
+			affected += count.Total
+		} else {
+			affected += count.Generated
+		}
+	}
+	if affected == 0 {
+		fmt.Println("✅ Nothing to clear")
+		return nil
+	}
+
+	if !opts.yes && isTerminal(os.Stdin) {
+		expected := "y"
+		prompt := fmt.Sprintf("   Delete %d documents? [y/N] ", affected)
+		if opts.mode == config.ClearDrop && !local {
+			expected = db.Name()
+			prompt = fmt.Sprintf("   Drop %d documents on a non-local host? Type the database name to confirm: ", affected)
+		}
+		fmt.Print(prompt)
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if !strings.EqualFold(strings.TrimSpace(answer), expected) {
+			return fmt.Errorf("aborted")
+		}
+	}
+
+	if opts.mode == config.ClearDrop {
+		if err := generator.DropCollections(ctx, db, names); err != nil {
+			return fmt.Errorf("failed to drop collections: %w", err)
+		}
+		fmt.Println("✅ Database cleared")
+		return nil
+	}
+
+	deleted, err := generator.DeleteGenerated(ctx, db, names, opts.generation)
+	if err != nil {
+		return fmt.Errorf("failed to delete generated documents: %w", err)
+	}
+	fmt.Printf("✅ Deleted %d generated documents\n", deleted)
+	return nil
+}
+
+func describeClear(opts clearOptions) string {
+	switch {
+	case opts.mode == config.ClearDrop:
+		return "drop collections"
+	case opts.generation != "":
+		return "generation " + opts.generation
+	default:
+		return "all generated documents"
+	}
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"load-test/internal/config"
+	"load-test/internal/generator"
+)
+
+func ensureIndexes(ctx context.Context, db *mongo.Database, collections config.CollectionNames, mode, specPath string) error {
+	if mode == config.IndexesSkip {
+		return nil
+	}
+
+	specs := generator.DefaultIndexSpecs()
+	source := "backend defaults"
+	if specPath != "" {
+		loaded, err := generator.LoadIndexSpecs(specPath)
+		if err != nil {
+			return err
+		}
+		specs = loaded
+		source = specPath
+	}
+
+	fmt.Printf("\n🗂️  %s indexes (spec: %s)\n", indexVerb(mode), source)
+	results, err := generator.EnsureIndexes(ctx, db, collectionMap(collections), specs, mode == config.IndexesCreate)
+	printIndexResults(results)
+	if err != nil {
+		return err
+	}
+
+	var buildTime time.Duration
+	problems := 0
+	for _, result := range results {
+		buildTime += result.BuildTime
+		switch result.Status {
+		case generator.IndexFailed, generator.IndexConflict, generator.IndexMissing:
+			problems++
+		}
+	}
+	fmt.Printf("   Index build time: %s\n", buildTime.Round(time.Millisecond))
+
+	if problems > 0 {
+		return fmt.Errorf("%d index(es) differ from the spec", problems)
+	}
+	fmt.Println("✅ Indexes match the spec")
+	return nil
+}
+
+func printIndexResults(results []generator.IndexResult) {
+	for _, result := range results {
+		keys := result.Keys
+		if result.Unique {
+			keys += " (unique)"
+		}
+		line := fmt.Sprintf("   %s %-9s %-20s %s", indexIcon(result.Status), result.Status, result.Collection, keys)
+		if result.Status == generator.IndexCreated {
+			line += fmt.Sprintf(" in %s", result.BuildTime.Round(time.Millisecond))
+		}
+		if result.Detail != "" {
+			line += " - " + result.Detail
+		}
+		fmt.Println(line)
+	}
+}
+
+func indexVerb(mode string) string {
+	if mode == config.IndexesVerify {
+		return "Verifying"
+	}
+	return "Creating"
+}
+
+func indexIcon(status string) string {
+	switch status {
+	case generator.IndexPresent, generator.IndexCreated:
+		return "✅"
+	case generator.IndexExtra:
+		return "➕"
+	default:
+		return "❌"
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"load-test/internal/config"
+	"load-test/internal/generator"
+)
+
+func runLoad(args []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	fs := flag.NewFlagSet("load", flag.ExitOnError)
+	config.FileFlags(fs)
+	bindMongoFlags(cfg, fs)
+	cfg.BindFlag(fs, "clear-mode", "generator.clearMode")
+	cfg.BindFlag(fs, "workers", "generator.workers")
+	cfg.BindFlag(fs, "batch-size", "generator.batchSize")
+	cfg.BindFlag(fs, "write-concern", "generator.writeConcern")
+	cfg.BindFlag(fs, "retries", "generator.retries")
+	cfg.BindFlag(fs, "indexes", "generator.indexes")
+	cfg.BindFlag(fs, "index-spec", "generator.indexSpec")
+	dirFlag := fs.String("dir", "", "Fixture directory written by -export (required)")
+	clearFlag := fs.Bool("clear", false, "Clear the target collections before loading")
+	forceFlag := fs.Bool("force", false, "Allow dropping collections on a non-local MongoDB host")
+	yesFlag := fs.Bool("yes", false, "Skip the interactive confirmation before clearing")
+	fs.Parse(args)
+
+	if *dirFlag == "" {
+		log.Fatal("load: -dir is required")
+	}
+	if err := cfg.Validate("mongodb", "generator", "output"); err != nil {
+		log.Fatal(err)
+	}
+	gen := cfg.Generator
+
+	manifest, err := generator.ReadManifest(*dirFlag)
+	hasManifest := err == nil
+	if err != nil && !os.IsNotExist(err) {
+		log.Fatal(err)
+	}
+	if hasManifest {
+		fmt.Printf("\n📦 Fixtures for dataset %s (generation %s, seed %d, created %s)\n",
+			datasetLabel(manifest.Dataset), manifest.Generation, manifest.Seed, manifest.CreatedAt.Format(time.RFC3339))
+	}
+	collections := cfg.MongoDB.CollectionNames()
+
+	ctx := context.Background()
+	db, disconnect := connect(ctx, cfg.MongoDB)
+	defer disconnect()
+
+	if *clearFlag {
+		clearOpts := clearOptions{mode: gen.ClearMode, force: *forceFlag, yes: *yesFlag}
+		if err := clearData(ctx, db, cfg.MongoDB, clearOpts); err != nil {
+			log.Fatalf("Failed to clear data: %v", err)
+		}
+	}
+
+	bulk := generator.DefaultBulkConfig()
+	bulk.Workers = gen.Workers
+	bulk.BatchSize = gen.BatchSize
+	bulk.WriteConcern = gen.WriteConcern
+	bulk.Retries = gen.Retries
+
+	startTime := time.Now()
+	loaded, err := generator.LoadFixtures(ctx, generator.NewCollections(db, collectionMap(collections)), *dirFlag, bulk)
+	if err != nil {
+		log.Fatalf("Failed to load fixtures: %v", err)
+	}
+	if len(loaded) == 0 {
+		log.Fatalf("No fixtures found in %s", *dirFlag)
+	}
+	duration := time.Since(startTime)
+
+	if err := ensureIndexes(ctx, db, collections, gen.Indexes, gen.IndexSpec); err != nil {
+		log.Fatalf("Index check failed: %v", err)
+	}
+
+	fmt.Printf("\n📊 Loaded into %s:\n", db.Name())
+	total := 0
+	for _, label := range generator.FixtureLabels {
+		if count, ok := loaded[label]; ok {
+			fmt.Printf("   %-13s %d\n", label+":", count)
+			total += count
+		}
+	}
+	fmt.Printf("   Load Time: %s (%.0f docs/s)\n", duration, float64(total)/duration.Seconds())
+
+	if hasManifest {
+		manifest.Database = db.Name()
+		manifest.Collections = collectionMap(collections)
+		manifest.Export = *dirFlag
+		if path, err := generator.WriteManifest(cfg.Output.ResultsDir, manifest); err != nil {
+			log.Printf("Warning: %v", err)
+		} else {
+			fmt.Printf("   Manifest: %s\n", path)
+		}
+	}
+	fmt.Println("\n✨ Fixtures loaded successfully!")
+	fmt.Println()
+}
+
+func datasetLabel(name string) string {
+	if name == "" {
+		return "(custom)"
+	}
+	return name
+}
+
+func printExport(export *generator.FixtureWriter) {
+	fmt.Printf("\n💾 Fixtures written to %s:\n", export.Dir())
+	counts := export.Counts()
+	for _, label := range generator.FixtureLabels {
+		if count, ok := counts[label]; ok {
+			fmt.Printf("   %-40s %d documents\n", export.Path(label), count)
+		}
+	}
+	if count, ok := counts["credentials"]; ok {
+		fmt.Printf("   %-40s %d plaintext test credentials\n", filepath.Join(export.Dir(), generator.CredentialsFile), count)
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"load-test/internal/config"
+	"load-test/internal/generator"
+	"load-test/internal/models"
+	"load-test/internal/report"
+)
+
+func runStats(args []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	config.FileFlags(fs)
+	bindMongoFlags(cfg, fs)
+	generationFlag := fs.String("generation", "", "Only analyze documents from this generation (default: whole collections)")
+	htmlFlag := fs.String("html", "", "Also write an HTML report to this file (relative names go to the results directory)")
+	assetsFlag := fs.String("assets", string(report.AssetsInline), "Chart assets for -html: inline or external")
+	fs.Parse(args)
+
+	if err := cfg.Validate("mongodb", "output"); err != nil {
+		log.Fatal(err)
+	}
+	assetMode, err := report.ParseAssetMode(*assetsFlag)
+	if err != nil {
+		log.Fatalf("Invalid -assets value: %v", err)
+	}
+	if *htmlFlag != "" {
+		if err := report.CheckAssets(assetMode); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	ctx := context.Background()
+	db, disconnect := connect(ctx, cfg.MongoDB)
+	defer disconnect()
+
+	if err := analyzeDataset(ctx, db, cfg, *generationFlag, *htmlFlag, assetMode); err != nil {
+		log.Fatalf("Dataset analysis failed: %v", err)
+	}
+}
+
+func analyzeDataset(ctx context.Context, db *mongo.Database, cfg *config.Config, generation, htmlPath string, assets report.AssetMode) error {
+	fmt.Printf("\n🔬 Analyzing %s", db.Name())
+	if generation != "" {
+		fmt.Printf(" (generation %s)", generation)
+	}
+	fmt.Println()
+
+	stats, err := generator.AnalyzeDataset(ctx, db, collectionMap(cfg.MongoDB.CollectionNames()), generation)
+	if err != nil {
+		return err
+	}
+	if manifest, err := generator.ReadManifest(cfg.Output.ResultsDir); err == nil && manifest.Database == db.Name() &&
+		(generation == "" || generation == manifest.Generation) {
+		stats.Dataset = manifest.Dataset
+		stats.Source = manifest.Source
+	}
+	printDatasetStats(stats)
+
+	if htmlPath == "" {
+		return nil
+	}
+	if !filepath.IsAbs(htmlPath) && filepath.Dir(htmlPath) == "." {
+		htmlPath = filepath.Join(cfg.Output.ResultsDir, htmlPath)
+	}
+	if err := report.GenerateDatasetReport(stats, htmlPath, assets); err != nil {
+		return err
+	}
+	fmt.Printf("\n📄 Dataset report saved to: %s\n", htmlPath)
+	return nil
+}
+
+func printDatasetStats(stats models.DatasetStats) {
+	if stats.Dataset != "" || stats.Source != "" {
+		fmt.Printf("   Dataset: %s (%s)\n", datasetLabel(stats.Dataset), stats.Source)
+	}
+	fmt.Printf("\n📊 Dataset Statistics:\n")
+	fmt.Printf("   Users:        %d (%d cold-start, %.1f%%)\n", stats.Users, stats.ColdStartUsers, percent(stats.ColdStartUsers, stats.Users))
+	fmt.Printf("   Movies:       %d (%d never interacted with, %.1f%%)\n", stats.Movies, stats.ColdStartMovies, percent(stats.ColdStartMovies, stats.Movies))
+	fmt.Printf("   Interactions: %d\n", stats.Interactions)
+	fmt.Printf("   Sparsity:     %.4f%% (%d distinct user-movie pairs, density %.3g)\n", stats.Sparsity*100, stats.UserMoviePairs, stats.Density)
+	if stats.UnknownUsers > 0 || stats.UnknownMovies > 0 {
+		fmt.Printf("   ⚠️  Interactions reference %d users and %d movies outside the analyzed set\n", stats.UnknownUsers, stats.UnknownMovies)
+	}
+
+	fmt.Printf("\n   %-10s %8s %7s %7s %7s %8s %6s %8s\n", "", "mean", "median", "p90", "p99", "max", "gini", "top 1%")
+	for _, row := range []struct {
+		name string
+		dist models.CountDistribution
+	}{{"per user", stats.PerUser}, {"per movie", stats.PerMovie}} {
+		fmt.Printf("   %-10s %8.2f %7d %7d %7d %8d %6.3f %7.1f%%\n", row.name, row.dist.Mean, row.dist.Median,
+			row.dist.P90, row.dist.P99, row.dist.Max, row.dist.Gini, row.dist.TopShare*100)
+	}
+
+	printCounts("Interactions per user (users)", stats.PerUser.Buckets)
+	printCounts("Interactions per movie (movies)", stats.PerMovie.Buckets)
+	printCounts("Interaction types", stats.Types)
+	printCounts("Ratings", stats.Ratings)
+
+	fmt.Printf("\n   Genres:\n")
+	for _, genre := range stats.Genres {
+		fmt.Printf("     %-16s %8d movies %10d interactions (%5.1f%%)\n", genre.Genre, genre.Movies, genre.Interactions, percent(genre.Interactions, stats.Interactions))
+	}
+}
+
+func printCounts(title string, counts []models.NamedCount) {
+	var total, largest int64
+	for _, c := range counts {
+		total += c.Count
+		largest = max(largest, c.Count)
+	}
+
+	fmt.Printf("\n   %s:\n", title)
+	for _, c := range counts {
+		bar := ""
+		if largest > 0 {
+			bar = strings.Repeat("█", int(c.Count*30/largest))
+		}
+		fmt.Printf("     %-12s %10d %5.1f%% %s\n", c.Name, c.Count, percent(c.Count, total), bar)
+	}
+}
+
+func percent(part, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total) * 100
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"load-test/internal/config"
+	"load-test/internal/generator"
+)
+
+func runVerify(args []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	config.FileFlags(fs)
+	bindMongoFlags(cfg, fs)
+	generationFlag := fs.String("generation", "", "Only check interactions from this generation (default: all)")
+	samplesFlag := fs.Int("samples", 5, "Example violations to print per check")
+	fs.Parse(args)
+
+	if err := cfg.Validate("mongodb"); err != nil {
+		log.Fatal(err)
+	}
+	collections := cfg.MongoDB.CollectionNames()
+
+	ctx := context.Background()
+	db, disconnect := connect(ctx, cfg.MongoDB)
+	defer disconnect()
+
+	fmt.Printf("\n🔍 Verifying %s.%s", db.Name(), collections.Interactions)
+	if *generationFlag != "" {
+		fmt.Printf(" (generation %s)", *generationFlag)
+	}
+	fmt.Println()
+
+	results, err := generator.VerifyInteractions(ctx, db, collectionMap(collections), *generationFlag, *samplesFlag)
+	if err != nil {
+		log.Fatalf("Verification failed: %v", err)
+	}
+
+	var violations int64
+	for _, result := range results {
+		violations += result.Violations
+		if result.Violations == 0 {
+			fmt.Printf("   ✅ %-22s 0\n", result.Check)
+			continue
+		}
+		fmt.Printf("   ❌ %-22s %d - %s\n", result.Check, result.Violations, result.Description)
+		for _, sample := range result.Samples {
+			fmt.Printf("        %s\n", formatSample(sample))
+		}
+	}
+
+	if violations > 0 {
+		fmt.Printf("\n❌ %d violation(s) found\n", violations)
+		disconnect()
+		os.Exit(1)
+	}
+	fmt.Println("\n✅ Interactions are consistent")
+}
+
+func bindMongoFlags(cfg *config.Config, fs *flag.FlagSet) {
+	cfg.BindFlag(fs, "mongo", "mongodb.uri")
+	cfg.BindFlag(fs, "db", "mongodb.database")
+	cfg.BindFlag(fs, "prefix", "mongodb.collectionPrefix")
+	cfg.BindFlag(fs, "users-collection", "mongodb.usersCollection")
+	cfg.BindFlag(fs, "movies-collection", "mongodb.moviesCollection")
+	cfg.BindFlag(fs, "interactions-collection", "mongodb.interactionsCollection")
+}
+
+func connect(ctx context.Context, mongoCfg config.MongoDBConfig) (*mongo.Database, func()) {
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(mongoCfg.ConnectionURI()))
+	if err != nil {
+		log.Fatalf("Failed to connect to MongoDB: %v", err)
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		log.Fatalf("Failed to ping MongoDB: %v", err)
+	}
+	fmt.Println("✅ Connected to MongoDB")
+	return client.Database(mongoCfg.DatabaseName()), func() { client.Disconnect(ctx) }
+}
+
+func collectionMap(collections config.CollectionNames) map[string]string {
+	return map[string]string{
+		"users":        collections.Users,
+		"movies":       collections.Movies,
+		"interactions": collections.Interactions,
+	}
+}
+
+func formatSample(sample bson.M) string {
+	data, err := bson.MarshalExtJSON(sample, false, false)
+	if err != nil {
+		return fmt.Sprint(sample)
+	}
+	return string(data)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"load-test/internal/config"
+	"load-test/internal/loadtest/capacity"
+	"load-test/internal/loadtest/executor"
+	"load-test/internal/loadtest/metrics"
+	"load-test/internal/models"
+)
+
+func runCapacityTest(ctx context.Context, cfg *config.Config, capCfg capacity.Config, users int, scenario string, collector *metrics.Collector, metricsChan chan<- models.Metric) (models.CapacityResult, error) {
+	fmt.Printf("⏳ Registering %d virtual users...\n", users)
+
+	pool, err := executor.NewPool(cfg.API.FullURL, users, metricsChan)
+	if err != nil {
+		return models.CapacityResult{}, err
+	}
+
+	fmt.Printf("✅ %d virtual users ready\n\n", pool.Size())
+
+	run := func(rate float64) models.CapacityStep {
+		fmt.Printf("  ▶ %6.1f it/s for %s ... ", rate, capCfg.StepDuration)
+
+		stepStart := time.Now()
+		rateResult := executor.RunArrivalRate(ctx, pool, rate, capCfg.StepDuration, scenario, metricsChan)
+		stepEnd := time.Now()
+
+		time.Sleep(100 * time.Millisecond)
+
+		measureFrom := stepStart.Add(capCfg.Warmup)
+		window := collector.Between(measureFrom, stepEnd)
+		step := capacity.EvaluateStep(rate, window, rateResult, stepEnd.Sub(measureFrom), cfg.SLO, capCfg.MaxDropRate)
+		step.StartedAt = stepStart
+
+		if step.Passed {
+			fmt.Printf("✅ P95 %.0f ms | %.1f req/s | success %.2f%%\n", step.P95Duration, step.Throughput, step.SuccessRate)
+		} else {
+			fmt.Printf("❌ %s\n", step.Reason)
+		}
+
+		return step
+	}
+
+	fmt.Printf("🔎 Searching for max sustainable rate (%s)...\n\n", capCfg.Strategy)
+	return capacity.Search(capCfg, run), nil
+}
+
+func printCapacitySummary(result models.CapacityResult) {
+	fmt.Printf("\n🚀 Capacity Search (%s)\n", result.Strategy)
+	fmt.Printf("═══════════════════════════════════════════════════════\n")
+	fmt.Printf("  %8s %10s %8s %9s %9s %8s %7s  %s\n", "it/s", "req/s", "success", "P95 ms", "P99 ms", "apdex", "drops", "result")
+	for _, step := range result.Steps {
+		status := "✅"
+		if !step.Passed {
+			status = "❌ " + step.Reason
+		}
+		fmt.Printf("  %8.1f %10.1f %7.2f%% %9.1f %9.1f %8.2f %7d  %s\n",
+			step.TargetRate, step.Throughput, step.SuccessRate, step.P95Duration, step.P99Duration,
+			step.Apdex, step.DroppedIterations, status)
+	}
+	fmt.Printf("═══════════════════════════════════════════════════════\n")
+
+	if result.MaxSustainableRate > 0 {
+		fmt.Printf("🏁 Max sustainable rate: %.1f iterations/sec\n", result.MaxSustainableRate)
+	} else {
+		fmt.Printf("⚠️  No tested rate met the SLO.\n")
+	}
+	if result.KneeRate > 0 {
+		fmt.Printf("📐 Latency knee at ~%.1f iterations/sec\n", result.KneeRate)
+	}
+}
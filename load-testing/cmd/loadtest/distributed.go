@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"load-test/internal/config"
+	"load-test/internal/loadtest/distributed"
+	"load-test/internal/loadtest/metrics"
+	"load-test/internal/models"
+)
+
+func controllerStages(spec string, users int, duration, rampUp time.Duration) ([]distributed.Stage, error) {
+	if spec != "" {
+		return distributed.ParseStages(spec)
+	}
+
+	var stages []distributed.Stage
+	if rampUp > 0 {
+		stages = append(stages, distributed.Stage{Duration: rampUp, Users: users})
+	}
+	stages = append(stages, distributed.Stage{Duration: duration, Users: users})
+	return stages, nil
+}
+
+func formatStages(stages []distributed.Stage) string {
+	parts := make([]string, len(stages))
+	for i, stage := range stages {
+		parts[i] = fmt.Sprintf("%s→%d", stage.Duration, stage.Users)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func runController(ctx context.Context, cfg *config.Config, stages []distributed.Stage, scenario, listen string, workers, localWorkers int) ([]metrics.HistogramSeries, []models.WorkerSummary, error) {
+	if localWorkers > workers {
+		workers = localWorkers
+	}
+
+	listener, err := net.Listen("tcp", listen)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to listen on %s: %w", listen, err)
+	}
+
+	plan := distributed.Plan{
+		TargetURL:      cfg.API.FullURL,
+		Scenario:       scenario,
+		Stages:         stages,
+		ReportInterval: 2 * time.Second,
+	}
+	controller := distributed.NewController(plan, workers)
+
+	serveCtx, stopServing := context.WithCancel(ctx)
+	defer stopServing()
+	go func() {
+		if err := controller.Serve(serveCtx, listener); err != nil {
+			fmt.Printf("⚠️  Controller server stopped: %v\n", err)
+		}
+	}()
+
+	fmt.Printf("🛰️  Controller listening on %s, waiting for %d workers...\n", listener.Addr(), workers)
+
+	var children sync.WaitGroup
+	if localWorkers > 0 {
+		port := listener.Addr().(*net.TCPAddr).Port
+		if err := spawnLocalWorkers(ctx, &children, localWorkers, fmt.Sprintf("http://127.0.0.1:%d", port)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	runErr := controller.Run(ctx, 2*time.Minute, 30*time.Second, func(msg string) {
+		fmt.Printf("  %s\n", msg)
+	})
+	children.Wait()
+	if runErr != nil {
+		return nil, nil, runErr
+	}
+	if pending := controller.Pending(); len(pending) > 0 {
+		fmt.Printf("⚠️  No final report from %s; results cover only the snapshots they sent before the drain timeout\n", strings.Join(pending, ", "))
+	}
+
+	var summaries []models.WorkerSummary
+	for _, worker := range controller.Workers() {
+		summaries = append(summaries, models.WorkerSummary{
+			ID:       worker.ID,
+			Name:     worker.Name,
+			Requests: worker.Requests,
+			Reports:  worker.Reports,
+			Final:    worker.Final,
+		})
+	}
+
+	return controller.Series(), summaries, nil
+}
+
+func spawnLocalWorkers(ctx context.Context, children *sync.WaitGroup, count int, controllerURL string) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate loadtest binary: %w", err)
+	}
+
+	for i := 0; i < count; i++ {
+		cmd := exec.CommandContext(ctx, executable,
+			"-mode", "worker",
+			"-controller", controllerURL,
+			"-worker-name", "local-"+strconv.Itoa(i+1))
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("failed to start local worker %d: %w", i+1, err)
+		}
+
+		children.Add(1)
+		go func() {
+			defer children.Done()
+			cmd.Wait()
+		}()
+	}
+
+	fmt.Printf("🧩 Started %d local worker processes\n", count)
+	return nil
+}
+
+func runWorker(ctx context.Context, controllerURL, name string) error {
+	worker := distributed.NewWorker(controllerURL, name)
+	if err := worker.Register(ctx, time.Minute); err != nil {
+		return err
+	}
+
+	plan := worker.Plan()
+	fmt.Printf("🔌 [%s] registered with %s (scenario %s, target %s)\n", worker.ID(), controllerURL, plan.Scenario, plan.TargetURL)
+
+	return worker.Run(ctx, func(msg string) {
+		fmt.Printf("  [%s] %s\n", worker.ID(), msg)
+	})
+}
+
+func printWorkerSummary(workers []models.WorkerSummary) {
+	fmt.Printf("\n🛰️  Workers\n")
+	fmt.Printf("═══════════════════════════════════════════════════════\n")
+	for _, worker := range workers {
+		fmt.Printf("  %s (%s): %d requests in %d reports", worker.ID, worker.Name, worker.Requests, worker.Reports)
+		if !worker.Final {
+			fmt.Printf(" (no final report)")
+		}
+		fmt.Printf("\n")
+	}
+	fmt.Printf("═══════════════════════════════════════════════════════\n")
+}
@@ -6,11 +6,14 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"load-test/internal/config"
-	"load-test/internal/loadtest/client"
+	"load-test/internal/loadtest/capacity"
+	"load-test/internal/loadtest/executor"
 	"load-test/internal/loadtest/metrics"
 	"load-test/internal/loadtest/scenarios"
 	"load-test/internal/models"
@@ -22,20 +25,99 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
-	usersFlag := flag.Int("users", cfg.LoadTest.ConcurrentUsers, "Number of concurrent users")
-	durationFlag := flag.Duration("duration", cfg.LoadTest.Duration, "Test duration")
-	rampUpFlag := flag.Duration("rampup", cfg.LoadTest.RampUp, "Ramp-up period")
-	scenarioFlag := flag.String("scenario", cfg.LoadTest.Scenario, "Scenario to run (auth|movies|recommendations|interactions|all)")
-	outputFlag := flag.String("output", cfg.Output.CSVOutput, "Output CSV file")
+	config.FileFlags(flag.CommandLine)
+	cfg.BindFlag(flag.CommandLine, "users", "loadTest.users")
+	cfg.BindFlag(flag.CommandLine, "duration", "loadTest.duration")
+	cfg.BindFlag(flag.CommandLine, "rampup", "loadTest.rampUp")
+	cfg.BindFlag(flag.CommandLine, "scenario", "loadTest.scenario")
+	cfg.BindFlag(flag.CommandLine, "output", "output.csv")
+	cfg.BindFlag(flag.CommandLine, "mode", "loadTest.mode")
+
+	var capCfg capacity.Config
+	flag.StringVar(&capCfg.Strategy, "capacity-strategy", capacity.StrategyStep, "Capacity search strategy (step|binary)")
+	flag.Float64Var(&capCfg.StartRate, "start-rate", 5, "Capacity mode: first arrival rate to try (iterations/sec)")
+	flag.Float64Var(&capCfg.MaxRate, "max-rate", 200, "Capacity mode: highest arrival rate to try (iterations/sec)")
+	flag.Float64Var(&capCfg.StepRate, "rate-step", 5, "Capacity mode: rate increment for the step strategy")
+	flag.Float64Var(&capCfg.Precision, "precision", 2, "Capacity mode: stop binary search once the bracket is this narrow")
+	flag.DurationVar(&capCfg.StepDuration, "step-duration", 30*time.Second, "Capacity mode: how long to hold each rate")
+	flag.DurationVar(&capCfg.Warmup, "step-warmup", 10*time.Second, "Capacity mode: initial part of each step excluded from evaluation")
+	flag.Float64Var(&capCfg.MaxDropRate, "max-drop-rate", 1, "Capacity mode: max %% of iterations dropped for lack of an idle virtual user")
+
+	var soakCfg metrics.SoakConfig
+	flag.DurationVar(&soakCfg.Window, "soak-window", 5*time.Minute, "Soak mode: length of the rolling windows used for trend analysis")
+	flag.DurationVar(&soakCfg.Step, "soak-step", time.Minute, "Soak mode: how far each rolling window advances (must divide -soak-window)")
+	flag.Float64Var(&soakCfg.DriftThreshold, "drift-threshold", 10, "Soak mode: flag latency/memory growth or throughput decay above this %% per hour")
+	flag.Float64Var(&soakCfg.ErrorDriftThreshold, "error-drift-threshold", 1, "Soak mode: flag error rate growth above this many percentage points per hour")
+	pollHealthFlag := flag.Bool("poll-health", false, "Soak mode: poll the backend /health endpoint")
+	pollMongoFlag := flag.Bool("poll-mongo", false, "Soak mode: poll MongoDB serverStatus memory")
+	pollIntervalFlag := flag.Duration("poll-interval", 30*time.Second, "Soak mode: resource polling interval")
+
+	listenFlag := flag.String("listen", ":7070", "Controller mode: address to accept workers on")
+	workersFlag := flag.Int("workers", 2, "Controller mode: number of workers to wait for")
+	localWorkersFlag := flag.Int("local-workers", 0, "Controller mode: spawn this many worker processes on this machine")
+	stagesFlag := flag.String("stages", "", "Controller mode: comma-separated duration:users stages (default: rampup then duration at -users)")
+	controllerFlag := flag.String("controller", "http://localhost:7070", "Worker mode: controller URL")
+	workerNameFlag := flag.String("worker-name", "", "Worker mode: name reported to the controller (default: hostname)")
 	flag.Parse()
 
+	sections := []string{"api", "loadTest", "output", "slo", "generator.password"}
+	if cfg.LoadTest.Mode == "soak" && *pollMongoFlag {
+		sections = append(sections, "mongodb")
+	}
+	if err := cfg.Validate(sections...); err != nil {
+		log.Fatal(err)
+	}
+	lt := cfg.LoadTest
+	scenarios.Password = cfg.Generator.Password.Reveal()
+
+	if lt.Mode == "worker" {
+		name := *workerNameFlag
+		if name == "" {
+			name, _ = os.Hostname()
+		}
+		if err := runWorker(context.Background(), *controllerFlag, name); err != nil {
+			log.Fatalf("Worker failed: %v", err)
+		}
+		return
+	}
+
+	stages, err := controllerStages(*stagesFlag, lt.ConcurrentUsers, lt.Duration, lt.RampUp)
+	if lt.Mode == "controller" && err != nil {
+		log.Fatalf("Invalid -stages value: %v", err)
+	}
+	if lt.Mode == "soak" && soakCfg.Window <= 0 {
+		log.Fatalf("Invalid soak settings: window must be positive, got %s", soakCfg.Window)
+	}
+	if lt.Mode == "soak" && (soakCfg.Step <= 0 || soakCfg.Step > soakCfg.Window || soakCfg.Window%soakCfg.Step != 0) {
+		log.Fatalf("Invalid soak settings: step must divide the %s window, got %s", soakCfg.Window, soakCfg.Step)
+	}
+	if lt.Mode == "capacity" {
+		if err := capCfg.Validate(); err != nil {
+			log.Fatalf("Invalid capacity settings: %v", err)
+		}
+	}
+
 	fmt.Printf("\n🚀 Starting Load Test\n")
 	fmt.Printf("═══════════════════════════════════════════════════════\n")
 	fmt.Printf("  API URL: %s\n", cfg.API.FullURL)
-	fmt.Printf("  Concurrent Users: %d\n", *usersFlag)
-	fmt.Printf("  Test Duration: %s\n", *durationFlag)
-	fmt.Printf("  Ramp-up Period: %s\n", *rampUpFlag)
-	fmt.Printf("  Scenario: %s\n", *scenarioFlag)
+	fmt.Printf("  Mode: %s\n", lt.Mode)
+	if lt.Mode == "capacity" {
+		fmt.Printf("  Virtual Users: %d\n", lt.ConcurrentUsers)
+		fmt.Printf("  Strategy: %s (%.1f → %.1f it/s)\n", capCfg.Strategy, capCfg.StartRate, capCfg.MaxRate)
+		fmt.Printf("  Step Duration: %s (warm-up %s)\n", capCfg.StepDuration, capCfg.Warmup)
+	} else {
+		fmt.Printf("  Concurrent Users: %d\n", lt.ConcurrentUsers)
+		fmt.Printf("  Test Duration: %s\n", lt.Duration)
+		fmt.Printf("  Ramp-up Period: %s\n", lt.RampUp)
+	}
+	if lt.Mode == "controller" {
+		fmt.Printf("  Workers: %d (%d local)\n", max(*workersFlag, *localWorkersFlag), *localWorkersFlag)
+		fmt.Printf("  Stages: %s\n", formatStages(stages))
+	}
+	if lt.Mode == "soak" {
+		fmt.Printf("  Trend Window: %s rolling every %s (drift > %.0f%%/h)\n", soakCfg.Window, soakCfg.Step, soakCfg.DriftThreshold)
+	}
+	fmt.Printf("  Scenario: %s\n", lt.Scenario)
 	fmt.Printf("═══════════════════════════════════════════════════════\n\n")
 
 	collector := metrics.NewCollector()
@@ -50,28 +132,92 @@ func main() {
 		}
 	}()
 
+	metadata := models.RunMetadata{
+		Mode:     lt.Mode,
+		Scenario: lt.Scenario,
+		Users:    lt.ConcurrentUsers,
+	}
+
+	var resources []models.ResourceSample
+	var series []metrics.HistogramSeries
+
 	startTime := time.Now()
-	runLoadTest(ctx, cfg.API.FullURL, *usersFlag, *durationFlag, *rampUpFlag, *scenarioFlag, metricsChan)
+	switch lt.Mode {
+	case "capacity":
+		result, err := runCapacityTest(ctx, cfg, capCfg, lt.ConcurrentUsers, lt.Scenario, collector, metricsChan)
+		if err != nil {
+			log.Fatalf("Capacity test failed: %v", err)
+		}
+		metadata.Capacity = &result
+	case "soak":
+		resources = runSoakTest(ctx, cfg, lt.ConcurrentUsers, lt.Duration, lt.RampUp, lt.Scenario, *pollHealthFlag, *pollMongoFlag, *pollIntervalFlag, metricsChan)
+	case "controller":
+		merged, workers, err := runController(ctx, cfg, stages, lt.Scenario, *listenFlag, *workersFlag, *localWorkersFlag)
+		if err != nil {
+			log.Fatalf("Distributed test failed: %v", err)
+		}
+		series = merged
+		metadata.Workers = workers
+		metadata.Users = 0
+		for _, stage := range stages {
+			metadata.Users = max(metadata.Users, stage.Users)
+		}
+	default:
+		runLoadTest(ctx, cfg.API.FullURL, lt.ConcurrentUsers, lt.Duration, lt.RampUp, lt.Scenario, metricsChan)
+	}
 	testDuration := time.Since(startTime)
+	metadata.StartedAt = startTime
+	metadata.Duration = testDuration
 
 	close(metricsChan)
 	time.Sleep(100 * time.Millisecond)
 
-	outputPath := cfg.Output.ResultsDir + "/" + *outputFlag
+	outputPath := cfg.Output.ResultsDir + "/" + cfg.Output.CSVOutput
 	if err := os.MkdirAll(cfg.Output.ResultsDir, 0755); err != nil {
 		log.Fatalf("Failed to create results directory: %v", err)
 	}
 
-	if err := collector.SaveToCSV(outputPath); err != nil {
+	saveCSV := collector.SaveToCSV
+	if lt.Mode == "controller" {
+		saveCSV = func(filename string) error { return metrics.SaveHistogramCSV(filename, series) }
+	}
+	if err := saveCSV(outputPath); err != nil {
 		log.Fatalf("Failed to save metrics: %v", err)
 	}
 
 	allMetrics := collector.GetMetrics()
+	if lt.Mode == "soak" {
+		soakReport := metrics.AnalyzeSoak(allMetrics, resources, soakCfg)
+		metadata.Soak = &soakReport
+	}
+
 	stats := metrics.CalculateStats(allMetrics)
+	sloReport := metrics.EvaluateSLO(allMetrics, cfg.SLO)
+	if lt.Mode == "controller" {
+		stats = metrics.CalculateHistogramStats(series)
+		sloReport = metrics.EvaluateHistogramSLO(series, cfg.SLO)
+		metadata.Aggregate = &models.AggregateResult{Stats: stats, SLO: sloReport}
+	}
+
+	if err := metrics.SaveMetadata(metrics.MetadataPath(outputPath), metadata); err != nil {
+		log.Fatalf("Failed to save run metadata: %v", err)
+	}
 
-	printSummary(stats, testDuration)
+	printSummary(stats, sloReport, testDuration)
+	if metadata.Capacity != nil {
+		printCapacitySummary(*metadata.Capacity)
+	}
+	if metadata.Soak != nil {
+		printSoakSummary(*metadata.Soak)
+	}
+	if len(metadata.Workers) > 0 {
+		printWorkerSummary(metadata.Workers)
+	}
 
 	fmt.Printf("\n✅ Results saved to: %s\n", outputPath)
+	if metadata.Aggregate != nil {
+		fmt.Printf("   Distributed run: the CSV holds one merged row per series instead of per-request rows; the report reads the totals from %s\n", metrics.MetadataPath(outputPath))
+	}
 	fmt.Printf("\n💡 Generate report: make report\n\n")
 }
 
@@ -89,7 +235,7 @@ func runLoadTest(ctx context.Context, baseURL string, users int, duration, rampU
 
 		go func(userId int) {
 			defer wg.Done()
-			runUserScenario(baseURL, userId, scenario, metricsChan, stopChan)
+			executor.RunVirtualUser(baseURL, userId, scenario, metricsChan, stopChan)
 		}(i)
 
 		if (i+1)%10 == 0 || i == users-1 {
@@ -122,49 +268,7 @@ func runLoadTest(ctx context.Context, baseURL string, users int, duration, rampU
 	}
 }
 
-func runUserScenario(baseURL string, userId int, scenario string, metricsChan chan<- models.Metric, stopChan <-chan struct{}) {
-	httpClient := client.NewHTTPClient(baseURL)
-
-	token, email, err := scenarios.Register(httpClient, userId, metricsChan)
-	if err != nil {
-		return
-	}
-
-	httpClient.SetToken(token)
-
-	movieIDs, _ := scenarios.GetAllMovies(httpClient, metricsChan)
-	if len(movieIDs) > 0 {
-		for _, movieID := range movieIDs[:min(5, len(movieIDs))] {
-			scenarios.RecordView(httpClient, movieID, metricsChan)
-		}
-	}
-
-	iterationDelay := time.Duration(100+userId*5) * time.Millisecond
-
-	for {
-		select {
-		case <-stopChan:
-			return
-		default:
-			switch scenario {
-			case "auth":
-				scenarios.RunAuthScenario(httpClient, email, metricsChan)
-			case "movies":
-				scenarios.RunMoviesScenario(httpClient, metricsChan)
-			case "recommendations":
-				scenarios.RunRecommendationsScenario(httpClient, metricsChan)
-			case "interactions":
-				scenarios.RunInteractionsScenario(httpClient, metricsChan)
-			case "all":
-				scenarios.RunAllScenarios(httpClient, email, metricsChan)
-			}
-
-			time.Sleep(iterationDelay)
-		}
-	}
-}
-
-func printSummary(stats models.TestStats, testDuration time.Duration) {
+func printSummary(stats models.TestStats, sloReport models.SLOReport, testDuration time.Duration) {
 	fmt.Printf("\n\n📊 Performance Test Summary\n")
 	fmt.Printf("═══════════════════════════════════════════════════════\n\n")
 
@@ -217,6 +321,41 @@ func printSummary(stats models.TestStats, testDuration time.Duration) {
 			ep.stats.Count, ep.stats.SuccessRate, ep.stats.AvgDuration, ep.stats.P95Duration)
 	}
 
+	fmt.Printf("\nStatus Codes:\n")
+	codes := make([]int, 0, len(stats.StatusCodes))
+	for code := range stats.StatusCodes {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	for _, code := range codes {
+		label := fmt.Sprintf("%d", code)
+		if code == 0 {
+			label = "no response"
+		}
+		fmt.Printf("  %s: %d\n", label, stats.StatusCodes[code])
+	}
+
+	if len(stats.ErrorCategories) > 0 {
+		fmt.Printf("\n🩺 Error Categories:\n")
+		categories := make([]string, 0, len(stats.ErrorCategories))
+		for category := range stats.ErrorCategories {
+			categories = append(categories, category)
+		}
+		sort.Slice(categories, func(i, j int) bool {
+			return stats.ErrorCategories[categories[i]] > stats.ErrorCategories[categories[j]]
+		})
+		for _, category := range categories {
+			fmt.Printf("  • %s: %d\n", category, stats.ErrorCategories[category])
+			for _, sample := range stats.ErrorSamples[category] {
+				sample = strings.ReplaceAll(sample, "\n", " ")
+				if len(sample) > 120 {
+					sample = sample[:120] + "…"
+				}
+				fmt.Printf("      ↳ %s\n", sample)
+			}
+		}
+	}
+
 	if len(stats.Errors) > 0 {
 		fmt.Printf("\n⚠️  Top Errors:\n")
 		type errorInfo struct {
@@ -241,14 +380,32 @@ func printSummary(stats models.TestStats, testDuration time.Duration) {
 		}
 	}
 
+	fmt.Printf("\nService Level Objectives:\n")
+	for i := 0; i < min(10, len(endpoints)); i++ {
+		slo, exists := sloReport.ByEndpoint[endpoints[i].endpoint]
+		if !exists {
+			continue
+		}
+		status := "✅"
+		if !slo.Met {
+			status = "❌"
+		}
+		fmt.Printf("  %s %s: Apdex %.2f (%s) | %.2f%% within %.0f ms (objective %.2f%%) | Budget left: %.0f%% | Max burn: %.1fx\n",
+			status, slo.Name, slo.Apdex, slo.Rating, slo.Compliance, slo.LatencyTargetMs,
+			slo.SuccessObjective, slo.BudgetRemaining, slo.MaxBurnRate)
+	}
+
+	overall := sloReport.Overall
 	fmt.Printf("\n═══════════════════════════════════════════════════════\n")
+	fmt.Printf("Apdex: %.2f (%s) | SLO compliance: %.2f%% (objective %.2f%%) | Error budget left: %.0f%%\n",
+		overall.Apdex, overall.Rating, overall.Compliance, overall.SuccessObjective, overall.BudgetRemaining)
 
-	if stats.SuccessRate >= 99 && stats.P95Duration < 200 {
+	if overall.Met && overall.Apdex >= 0.94 {
 		fmt.Printf("🎉 Excellent performance! All metrics within target.\n")
-	} else if stats.SuccessRate >= 95 && stats.P95Duration < 500 {
-		fmt.Printf("✅ Good performance. Some room for improvement.\n")
+	} else if overall.Met {
+		fmt.Printf("✅ SLO met. Some room for improvement.\n")
 	} else {
-		fmt.Printf("⚠️  Performance needs attention. Check errors and response times.\n")
+		fmt.Printf("⚠️  SLO missed: error budget exhausted. Check errors and response times.\n")
 	}
 }
 
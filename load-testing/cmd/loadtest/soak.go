@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"load-test/internal/config"
+	"load-test/internal/loadtest/metrics"
+	"load-test/internal/loadtest/monitor"
+	"load-test/internal/models"
+)
+
+func runSoakTest(ctx context.Context, cfg *config.Config, users int, duration, rampUp time.Duration, scenario string, pollHealth, pollMongo bool, pollInterval time.Duration, metricsChan chan<- models.Metric) []models.ResourceSample {
+	if !pollHealth && !pollMongo {
+		runLoadTest(ctx, cfg.API.FullURL, users, duration, rampUp, scenario, metricsChan)
+		return nil
+	}
+
+	healthURL := ""
+	if pollHealth {
+		healthURL = cfg.API.URL + "/health"
+	}
+	poller := monitor.NewPoller(healthURL, pollInterval)
+
+	if pollMongo {
+		if err := poller.ConnectMongo(ctx, cfg.MongoDB.ConnectionURI()); err != nil {
+			fmt.Printf("⚠️  MongoDB polling disabled: %v\n", err)
+		} else {
+			defer poller.Close(context.Background())
+		}
+	}
+
+	fmt.Printf("📡 Polling resources every %s\n\n", pollInterval)
+
+	pollCtx, stopPolling := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		poller.Run(pollCtx)
+		close(done)
+	}()
+
+	runLoadTest(ctx, cfg.API.FullURL, users, duration, rampUp, scenario, metricsChan)
+
+	stopPolling()
+	<-done
+
+	return poller.Samples()
+}
+
+func printSoakSummary(report models.SoakReport) {
+	fmt.Printf("\n🕰️  Soak Trends (%s windows every %s, %d windows overall)\n",
+		time.Duration(report.WindowSeconds*float64(time.Second)), time.Duration(report.StepSeconds*float64(time.Second)), len(report.Overall.Windows))
+	fmt.Printf("═══════════════════════════════════════════════════════\n")
+
+	printSeries := func(series models.SoakSeries) {
+		status := "✅"
+		if series.Drifting {
+			status = "❌"
+		}
+		fmt.Printf("  %s %s:\n", status, series.Name)
+		for _, trend := range series.Trends {
+			printTrend(trend)
+		}
+	}
+
+	printSeries(report.Overall)
+
+	var endpoints []string
+	for endpoint := range report.ByEndpoint {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+	for _, endpoint := range endpoints {
+		if series := report.ByEndpoint[endpoint]; series.Drifting {
+			printSeries(series)
+		}
+	}
+
+	if len(report.ResourceTrends) > 0 {
+		fmt.Printf("  📡 resources (%d samples):\n", len(report.Resources))
+		for _, trend := range report.ResourceTrends {
+			printTrend(trend)
+		}
+	}
+
+	fmt.Printf("═══════════════════════════════════════════════════════\n")
+	if report.Drifting {
+		fmt.Printf("⚠️  Significant drift detected (threshold %.0f%%/h, errors %.1f pp/h).\n", report.DriftThreshold, report.ErrorDriftThreshold)
+	} else {
+		fmt.Printf("✅ No significant drift detected.\n")
+	}
+}
+
+func printTrend(trend models.TrendResult) {
+	marker := " "
+	if trend.Drifting {
+		marker = "⚠"
+	}
+
+	change := fmt.Sprintf("%+.1f%%/h", trend.ChangePerHour)
+	if trend.Metric == metrics.TrendErrorRate {
+		change = fmt.Sprintf("%+.2f pp/h", trend.SlopePerHour)
+	}
+
+	significance := "not significant"
+	if trend.Significant {
+		significance = "significant"
+	}
+
+	fmt.Printf("     %s %-18s baseline %8.2f | %12s | R² %.2f | %s\n", marker, trend.Metric, trend.Baseline, change, trend.RSquared, significance)
+}
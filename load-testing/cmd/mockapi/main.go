@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"load-test/internal/config"
+	"load-test/internal/mockapi"
+)
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	mockCfg := mockapi.DefaultConfig()
+	flagCfg := mockCfg.Default
+
+	listenFlag := flag.String("listen", ":3000", "Address to listen on")
+	basePathFlag := flag.String("base-path", cfg.API.BasePath, "Prefix for API routes (/health is always served at the root)")
+	moviesFlag := flag.Int("movies", 500, "Number of movies to seed")
+	fixturesFlag := flag.String("fixtures", "", "Seed users, movies and interactions from a generator fixture directory instead of -movies")
+	routesFlag := flag.String("routes", "", "JSON file with per-route latency and error settings (explicitly set -latency* and -error-* flags override its default)")
+	seedFlag := flag.Int64("seed", time.Now().UnixNano(), "Random seed for data, latency and error injection")
+	flag.StringVar(&flagCfg.Latency.Distribution, "latency", flagCfg.Latency.Distribution, "Default latency distribution (fixed|uniform|normal|lognormal)")
+	flag.DurationVar(&flagCfg.Latency.Median, "latency-median", flagCfg.Latency.Median, "Default median latency (fixed, normal, lognormal)")
+	flag.DurationVar(&flagCfg.Latency.P99, "latency-p99", flagCfg.Latency.P99, "Default P99 latency (lognormal)")
+	flag.DurationVar(&flagCfg.Latency.StdDev, "latency-stddev", 0, "Default latency standard deviation (normal)")
+	flag.DurationVar(&flagCfg.Latency.Min, "latency-min", 0, "Default minimum latency (uniform)")
+	flag.DurationVar(&flagCfg.Latency.Max, "latency-max", 0, "Cap on sampled latency; upper bound for uniform")
+	flag.Float64Var(&flagCfg.ErrorRate, "error-rate", 0, "Default %% of requests answered with -error-status")
+	flag.IntVar(&flagCfg.ErrorStatus, "error-status", flagCfg.ErrorStatus, "Status code for injected errors")
+	config.FileFlags(flag.CommandLine)
+	flag.Parse()
+
+	if err := cfg.Validate("api"); err != nil {
+		log.Fatal(err)
+	}
+
+	applyFlags := func(route *mockapi.RouteConfig) {
+		flag.Visit(func(f *flag.Flag) {
+			switch f.Name {
+			case "latency":
+				route.Latency.Distribution = flagCfg.Latency.Distribution
+			case "latency-median":
+				route.Latency.Median = flagCfg.Latency.Median
+			case "latency-p99":
+				route.Latency.P99 = flagCfg.Latency.P99
+			case "latency-stddev":
+				route.Latency.StdDev = flagCfg.Latency.StdDev
+			case "latency-min":
+				route.Latency.Min = flagCfg.Latency.Min
+			case "latency-max":
+				route.Latency.Max = flagCfg.Latency.Max
+			case "error-rate":
+				route.ErrorRate = flagCfg.ErrorRate
+			case "error-status":
+				route.ErrorStatus = flagCfg.ErrorStatus
+			}
+		})
+	}
+	if *routesFlag != "" {
+		if err := mockapi.LoadConfig(*routesFlag, &mockCfg, applyFlags); err != nil {
+			log.Fatalf("Failed to load mock config: %v", err)
+		}
+	} else {
+		applyFlags(&mockCfg.Default)
+	}
+	if err := mockCfg.Default.Validate(); err != nil {
+		log.Fatalf("Invalid default settings: %v", err)
+	}
+
+	gofakeit.Seed(*seedFlag)
+
+	fmt.Printf("\n🧪 Starting Mock Movie API\n")
+	fmt.Printf("═══════════════════════════════════════════════════════\n")
+	fmt.Printf("  Listen: %s (routes under %s)\n", *listenFlag, *basePathFlag)
+	if *fixturesFlag != "" {
+		fmt.Printf("  Fixtures: %s\n", *fixturesFlag)
+	} else {
+		fmt.Printf("  Movies: %d\n", *moviesFlag)
+	}
+	fmt.Printf("  Default latency: %s\n", describeLatency(mockCfg.Default.Latency))
+	fmt.Printf("  Default error rate: %.2f%% (HTTP %d)\n", mockCfg.Default.ErrorRate, mockCfg.Default.ErrorStatus)
+	for route, routeCfg := range mockCfg.Routes {
+		fmt.Printf("  %s: %s, %.2f%% errors\n", route, describeLatency(routeCfg.Latency), routeCfg.ErrorRate)
+	}
+	fmt.Printf("  Seed: %d\n", *seedFlag)
+	fmt.Printf("═══════════════════════════════════════════════════════\n\n")
+
+	var store *mockapi.Store
+	if *fixturesFlag != "" {
+		store = mockapi.NewStore(0, *seedFlag)
+		counts, err := store.LoadFixtures(*fixturesFlag)
+		if err != nil {
+			log.Fatalf("Failed to load fixtures: %v", err)
+		}
+		fmt.Printf("📦 Loaded %d users, %d movies and %d interactions from %s\n\n", counts["users"], counts["movies"], counts["interactions"], *fixturesFlag)
+	} else {
+		store = mockapi.NewStore(*moviesFlag, *seedFlag)
+	}
+	server := mockapi.NewServer(store, mockCfg, *basePathFlag, *seedFlag)
+
+	httpServer := &http.Server{Addr: *listenFlag, Handler: server}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Printf("✅ Listening on %s — press Ctrl+C to stop\n", *listenFlag)
+	if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatalf("Mock API failed: %v", err)
+	}
+
+	users, movies, interactions := store.Counts()
+	fmt.Printf("\n\n📊 Mock API Summary\n")
+	fmt.Printf("═══════════════════════════════════════════════════════\n")
+	fmt.Printf("  Users: %d | Movies: %d | Interactions: %d\n\n", users, movies, interactions)
+	for _, stats := range server.Stats() {
+		fmt.Printf("  %-40s %8d requests %6d injected errors\n", stats.Route, stats.Requests, stats.Injected)
+	}
+	fmt.Printf("═══════════════════════════════════════════════════════\n\n")
+}
+
+func describeLatency(latency mockapi.Latency) string {
+	switch latency.Distribution {
+	case mockapi.DistributionUniform:
+		return fmt.Sprintf("uniform %s–%s", latency.Min, latency.Max)
+	case mockapi.DistributionNormal:
+		return fmt.Sprintf("normal median %s ± %s", latency.Median, latency.StdDev)
+	case mockapi.DistributionLogNormal:
+		return fmt.Sprintf("lognormal median %s, p99 %s", latency.Median, latency.P99)
+	default:
+		return fmt.Sprintf("fixed %s", latency.Median)
+	}
+}
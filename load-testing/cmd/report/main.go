@@ -1,13 +1,16 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"load-test/internal/config"
+	loadmetrics "load-test/internal/loadtest/metrics"
 	"load-test/internal/report"
 )
 
@@ -18,9 +21,32 @@ func main() {
 	}
 
 	inputFlag := flag.String("input", "", "Input CSV file (default: latest in results/)")
-	outputFlag := flag.String("output", cfg.Output.ReportOutput, "Output HTML file")
+	outputFlag := flag.String("output", cfg.Output.ReportOutput, "Output file (extension follows -format when left at the default)")
+	formatFlag := flag.String("format", string(report.FormatHTML), "Report format (html|md|junit|json)")
+	faultsFlag := flag.String("faults", "", "Fault record from cmd/chaosproxy to overlay on the time-series charts")
+	assetsFlag := flag.String("assets", string(report.AssetsInline), "Chart assets: inline (self-contained, needs the vendored Chart.js from go generate ./internal/report) or external (load from CDN)")
+	config.FileFlags(flag.CommandLine)
 	flag.Parse()
 
+	if err := cfg.Validate("output", "slo"); err != nil {
+		log.Fatal(err)
+	}
+
+	assetMode, err := report.ParseAssetMode(*assetsFlag)
+	if err != nil {
+		log.Fatalf("Invalid -assets value: %v", err)
+	}
+
+	format, err := report.ParseFormat(*formatFlag)
+	if err != nil {
+		log.Fatalf("Invalid -format value: %v", err)
+	}
+	if format == report.FormatHTML {
+		if err := report.CheckAssets(assetMode); err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	inputFile := *inputFlag
 	if inputFile == "" {
 		inputFile, err = findLatestCSV(cfg.Output.ResultsDir)
@@ -29,30 +55,58 @@ func main() {
 		}
 	}
 
-	outputFile := filepath.Join(cfg.Output.ResultsDir, *outputFlag)
+	outputName := *outputFlag
+	if outputName == cfg.Output.ReportOutput {
+		outputName = strings.TrimSuffix(outputName, filepath.Ext(outputName)) + format.Extension()
+	}
+	outputFile := filepath.Join(cfg.Output.ResultsDir, outputName)
 
 	fmt.Printf("\n📊 Generating Performance Report\n")
 	fmt.Printf("═══════════════════════════════════════════════════════\n")
 	fmt.Printf("  Input:  %s\n", inputFile)
 	fmt.Printf("  Output: %s\n", outputFile)
+	fmt.Printf("  Format: %s\n", format)
+	if format == report.FormatHTML {
+		fmt.Printf("  Assets: %s\n", assetMode)
+	}
 	fmt.Printf("═══════════════════════════════════════════════════════\n\n")
 
+	metadata, err := report.LoadMetadata(loadmetrics.MetadataPath(inputFile))
+	if err != nil {
+		log.Fatalf("Failed to load run metadata: %v", err)
+	}
+
 	fmt.Printf("⏳ Loading metrics from CSV...\n")
 	metrics, err := report.LoadMetricsFromCSV(inputFile)
-	if err != nil {
+	switch {
+	case (errors.Is(err, report.ErrAggregatedCSV) || errors.Is(err, report.ErrNoMetrics)) && metadata != nil && metadata.Aggregate != nil:
+		fmt.Printf("✅ Distributed run: using %d requests aggregated from worker histograms\n\n", metadata.Aggregate.Stats.TotalRequests)
+	case err != nil:
 		log.Fatalf("Failed to load metrics: %v", err)
+	default:
+		fmt.Printf("✅ Loaded %d metrics\n\n", len(metrics))
 	}
-	fmt.Printf("✅ Loaded %d metrics\n\n", len(metrics))
 
 	fmt.Printf("📈 Analyzing performance data...\n")
-	fmt.Printf("⚙️  Generating charts and tables...\n")
+	fmt.Printf("⚙️  Rendering %s report...\n", format)
 
-	if err := report.GenerateHTMLReport(metrics, outputFile); err != nil {
+	opts := report.Options{Assets: assetMode, SLO: cfg.SLO, Metadata: metadata}
+	if *faultsFlag != "" {
+		if opts.Faults, err = report.LoadFaultLog(*faultsFlag); err != nil {
+			log.Fatalf("Failed to load fault record: %v", err)
+		}
+		fmt.Printf("🌪️  Overlaying %d fault phases from %s\n", len(opts.Faults.Phases), *faultsFlag)
+	}
+	if err := report.GenerateReport(metrics, outputFile, format, opts); err != nil {
 		log.Fatalf("Failed to generate report: %v", err)
 	}
 
 	fmt.Printf("\n✅ Report generated successfully!\n")
 	fmt.Printf("\n📄 Report saved to: %s\n", outputFile)
+	if format != report.FormatHTML {
+		fmt.Printf("\n")
+		return
+	}
 	fmt.Printf("\n💡 Open the report:\n")
 	fmt.Printf("   - Linux:   xdg-open %s\n", outputFile)
 	fmt.Printf("   - macOS:   open %s\n", outputFile)
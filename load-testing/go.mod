@@ -2,17 +2,21 @@ module load-test
 
 go 1.24.5
 
+require (
+	github.com/joho/godotenv v1.5.1
+	github.com/montanaflynn/stats v0.7.1
+	go.mongodb.org/mongo-driver v1.17.6
+	gopkg.in/yaml.v3 v3.0.1
+)
+
 require (
 	github.com/brianvoe/gofakeit/v6 v6.28.0 // indirect
 	github.com/golang/snappy v0.0.4 // indirect
-	github.com/joho/godotenv v1.5.1 // indirect
 	github.com/klauspost/compress v1.16.7 // indirect
-	github.com/montanaflynn/stats v0.7.1 // indirect
 	github.com/xdg-go/pbkdf2 v1.0.0 // indirect
 	github.com/xdg-go/scram v1.1.2 // indirect
 	github.com/xdg-go/stringprep v1.0.4 // indirect
 	github.com/youmark/pkcs8 v0.0.0-20240726163527-a2c0da244d78 // indirect
-	go.mongodb.org/mongo-driver v1.17.6 // indirect
 	golang.org/x/crypto v0.26.0 // indirect
 	golang.org/x/sync v0.8.0 // indirect
 	golang.org/x/text v0.17.0 // indirect
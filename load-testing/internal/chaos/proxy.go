@@ -0,0 +1,228 @@
+package chaos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	passthroughPhase = "passthrough"
+	throttleChunk    = 256
+)
+
+type Proxy struct {
+	schedule Schedule
+	basePath string
+	recorder *Recorder
+	start    time.Time
+	api      *httputil.ReverseProxy
+	origin   *httputil.ReverseProxy
+
+	rngMu sync.Mutex
+	rng   *rand.Rand
+}
+
+func NewProxy(target, basePath string, schedule Schedule, recorder *Recorder, seed int64) (*Proxy, error) {
+	targetURL, err := url.Parse(target)
+	if err != nil || targetURL.Host == "" {
+		return nil, fmt.Errorf("invalid proxy target %q", target)
+	}
+	originURL := &url.URL{Scheme: targetURL.Scheme, Host: targetURL.Host}
+
+	p := &Proxy{
+		schedule: schedule,
+		basePath: strings.TrimSuffix(basePath, "/"),
+		recorder: recorder,
+		start:    time.Now(),
+		api:      httputil.NewSingleHostReverseProxy(targetURL),
+		origin:   httputil.NewSingleHostReverseProxy(originURL),
+		rng:      rand.New(rand.NewSource(seed)),
+	}
+	p.api.ErrorHandler = upstreamError
+	p.origin.ErrorHandler = upstreamError
+
+	p.Tick(p.start)
+	return p, nil
+}
+
+func (p *Proxy) Tick(now time.Time) *Phase {
+	idx, iteration, ok := p.schedule.At(now.Sub(p.start))
+	if !ok {
+		p.recorder.Enter(passthroughPhase, passthroughPhase, now)
+		return nil
+	}
+
+	phase := &p.schedule.Phases[idx]
+	p.recorder.Enter(fmt.Sprintf("%d/%d", iteration, idx), phase.Name, now)
+	return phase
+}
+
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	phase := p.Tick(time.Now())
+	p.recorder.Request()
+
+	route, underAPI := strings.CutPrefix(r.URL.Path, p.basePath)
+	if p.basePath == "" {
+		route, underAPI = r.URL.Path, true
+	}
+
+	var delay, chunkDelay time.Duration
+	var bandwidth int
+	var failure *Fault
+
+	if phase != nil {
+		for i := range phase.Faults {
+			fault := &phase.Faults[i]
+			if !MatchRoute(fault.Route, route) || !p.roll(fault.Probability) {
+				continue
+			}
+			p.recorder.Injected(fault.Type, fault.Route)
+
+			switch fault.Type {
+			case FaultLatency:
+				delay += fault.Latency + p.jitter(fault.Jitter)
+			case FaultSlowBody:
+				chunkDelay = fault.Latency
+			case FaultBandwidth:
+				bandwidth = fault.Bandwidth
+			case FaultReset, FaultError:
+				if failure == nil {
+					failure = fault
+				}
+			}
+		}
+	}
+
+	if delay > 0 && !sleep(r.Context(), delay) {
+		return
+	}
+
+	if failure != nil {
+		if failure.Type == FaultReset {
+			resetConnection(w)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(failure.Status)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]interface{}{"message": "Injected by chaosproxy", "statusCode": failure.Status},
+		})
+		return
+	}
+
+	if chunkDelay > 0 || bandwidth > 0 {
+		w = &throttledWriter{ResponseWriter: w, ctx: r.Context(), chunkDelay: chunkDelay, bandwidth: bandwidth}
+	}
+
+	if underAPI {
+		r.URL.Path = route
+		r.URL.RawPath = ""
+		p.api.ServeHTTP(w, r)
+		return
+	}
+	p.origin.ServeHTTP(w, r)
+}
+
+func (p *Proxy) roll(probability float64) bool {
+	if probability >= 100 {
+		return true
+	}
+	p.rngMu.Lock()
+	defer p.rngMu.Unlock()
+	return p.rng.Float64()*100 < probability
+}
+
+func (p *Proxy) jitter(jitter time.Duration) time.Duration {
+	if jitter <= 0 {
+		return 0
+	}
+	p.rngMu.Lock()
+	defer p.rngMu.Unlock()
+	return time.Duration((p.rng.Float64()*2 - 1) * float64(jitter))
+}
+
+func resetConnection(w http.ResponseWriter) {
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "connection reset unsupported", http.StatusBadGateway)
+		return
+	}
+
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		return
+	}
+	if tcp, ok := conn.(*net.TCPConn); ok {
+		tcp.SetLinger(0)
+	}
+	conn.Close()
+}
+
+func upstreamError(w http.ResponseWriter, _ *http.Request, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadGateway)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{"message": "chaosproxy upstream error: " + err.Error(), "statusCode": http.StatusBadGateway},
+	})
+}
+
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+	select {
+	case <-time.After(d):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+type throttledWriter struct {
+	http.ResponseWriter
+	ctx        context.Context
+	chunkDelay time.Duration
+	bandwidth  int
+}
+
+func (t *throttledWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := throttleChunk
+		if n > len(p) {
+			n = len(p)
+		}
+
+		wait := t.chunkDelay
+		if t.bandwidth > 0 {
+			wait += time.Duration(float64(n) / float64(t.bandwidth) * float64(time.Second))
+		}
+		if !sleep(t.ctx, wait) {
+			return written, t.ctx.Err()
+		}
+
+		m, err := t.ResponseWriter.Write(p[:n])
+		written += m
+		if err != nil {
+			return written, err
+		}
+		t.Flush()
+		p = p[n:]
+	}
+	return written, nil
+}
+
+func (t *throttledWriter) Flush() {
+	if flusher, ok := t.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,98 @@
+package chaos
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"load-test/internal/models"
+	"load-test/internal/secret"
+)
+
+type faultKey struct {
+	faultType string
+	route     string
+}
+
+type Recorder struct {
+	mu      sync.Mutex
+	log     models.FaultLog
+	current string
+	counts  map[faultKey]int
+}
+
+func NewRecorder(target string) *Recorder {
+	return &Recorder{
+		log:    models.FaultLog{Target: secret.MaskURI(target), StartedAt: time.Now()},
+		counts: make(map[faultKey]int),
+	}
+}
+
+func (r *Recorder) Enter(key, name string, at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if key == r.current {
+		return
+	}
+	r.closePhase(at)
+
+	r.current = key
+	r.log.Phases = append(r.log.Phases, models.FaultPhase{Name: name, Start: at})
+}
+
+func (r *Recorder) Request() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.log.Phases) > 0 {
+		r.log.Phases[len(r.log.Phases)-1].Requests++
+	}
+}
+
+func (r *Recorder) Injected(faultType, route string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.counts[faultKey{faultType, route}]++
+}
+
+func (r *Recorder) Snapshot() models.FaultLog {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	log := r.log
+	log.Phases = append([]models.FaultPhase(nil), r.log.Phases...)
+	if n := len(log.Phases); n > 0 && log.Phases[n-1].End.IsZero() {
+		log.Phases[n-1].End = time.Now()
+		log.Phases[n-1].Injected = countList(r.counts)
+	}
+	return log
+}
+
+func (r *Recorder) Save(path string) error {
+	data, err := json.MarshalIndent(r.Snapshot(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func (r *Recorder) closePhase(at time.Time) {
+	if len(r.log.Phases) == 0 {
+		return
+	}
+	last := &r.log.Phases[len(r.log.Phases)-1]
+	last.End = at
+	last.Injected = countList(r.counts)
+	r.counts = make(map[faultKey]int)
+}
+
+func countList(counts map[faultKey]int) []models.FaultCount {
+	list := make([]models.FaultCount, 0, len(counts))
+	for key, count := range counts {
+		list = append(list, models.FaultCount{Type: key.faultType, Route: key.route, Count: count})
+	}
+	return list
+}
@@ -0,0 +1,208 @@
+package chaos
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	FaultLatency   = "latency"
+	FaultBandwidth = "bandwidth"
+	FaultReset     = "reset"
+	FaultError     = "error"
+	FaultSlowBody  = "slow_body"
+)
+
+type Schedule struct {
+	Phases []Phase
+	Loop   bool
+}
+
+type Phase struct {
+	Name     string
+	Duration time.Duration
+	Faults   []Fault
+}
+
+type Fault struct {
+	Type        string
+	Route       string
+	Probability float64
+	Latency     time.Duration
+	Jitter      time.Duration
+	Bandwidth   int
+	Status      int
+}
+
+type fileFault struct {
+	Type        string   `json:"type"`
+	Route       string   `json:"route"`
+	Probability *float64 `json:"probability"`
+	Latency     string   `json:"latency"`
+	Jitter      string   `json:"jitter"`
+	Bandwidth   int      `json:"bandwidth"`
+	Status      int      `json:"status"`
+}
+
+type filePhase struct {
+	Name     string      `json:"name"`
+	Duration string      `json:"duration"`
+	Faults   []fileFault `json:"faults"`
+}
+
+type scheduleFile struct {
+	Loop   bool        `json:"loop"`
+	Phases []filePhase `json:"phases"`
+}
+
+func LoadSchedule(path string) (Schedule, error) {
+	var schedule Schedule
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return schedule, fmt.Errorf("failed to read chaos schedule: %w", err)
+	}
+
+	var file scheduleFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return schedule, fmt.Errorf("failed to parse chaos schedule %s: %w", path, err)
+	}
+
+	schedule.Loop = file.Loop
+	for i, fp := range file.Phases {
+		phase := Phase{Name: fp.Name}
+		if phase.Name == "" {
+			phase.Name = fmt.Sprintf("phase-%d", i+1)
+		}
+		if phase.Duration, err = time.ParseDuration(fp.Duration); err != nil || phase.Duration <= 0 {
+			return schedule, fmt.Errorf("chaos schedule %s: phase %s: invalid duration %q", path, phase.Name, fp.Duration)
+		}
+
+		for _, ff := range fp.Faults {
+			fault, err := ff.toFault()
+			if err != nil {
+				return schedule, fmt.Errorf("chaos schedule %s: phase %s: %w", path, phase.Name, err)
+			}
+			phase.Faults = append(phase.Faults, fault)
+		}
+		schedule.Phases = append(schedule.Phases, phase)
+	}
+
+	if len(schedule.Phases) == 0 {
+		return schedule, fmt.Errorf("chaos schedule %s defines no phases", path)
+	}
+	return schedule, nil
+}
+
+func (f fileFault) toFault() (Fault, error) {
+	fault := Fault{
+		Type:        f.Type,
+		Route:       f.Route,
+		Probability: 100,
+		Bandwidth:   f.Bandwidth,
+		Status:      f.Status,
+	}
+	if fault.Route == "" {
+		fault.Route = "*"
+	}
+	if f.Probability != nil {
+		fault.Probability = *f.Probability
+	}
+
+	var err error
+	if f.Latency != "" {
+		if fault.Latency, err = time.ParseDuration(f.Latency); err != nil {
+			return fault, fmt.Errorf("invalid latency %q: %w", f.Latency, err)
+		}
+	}
+	if f.Jitter != "" {
+		if fault.Jitter, err = time.ParseDuration(f.Jitter); err != nil {
+			return fault, fmt.Errorf("invalid jitter %q: %w", f.Jitter, err)
+		}
+	}
+
+	return fault, fault.Validate()
+}
+
+func (f Fault) Validate() error {
+	if f.Probability < 0 || f.Probability > 100 {
+		return fmt.Errorf("%s fault: probability must be in [0, 100], got %g", f.Type, f.Probability)
+	}
+
+	switch f.Type {
+	case FaultLatency:
+		if f.Latency <= 0 && f.Jitter <= 0 {
+			return fmt.Errorf("latency fault needs latency or jitter")
+		}
+	case FaultSlowBody:
+		if f.Latency <= 0 {
+			return fmt.Errorf("slow_body fault needs a per-chunk latency")
+		}
+	case FaultBandwidth:
+		if f.Bandwidth <= 0 {
+			return fmt.Errorf("bandwidth fault needs bandwidth in bytes/sec")
+		}
+	case FaultError:
+		if f.Status < 400 || f.Status > 599 {
+			return fmt.Errorf("error fault needs a 4xx or 5xx status, got %d", f.Status)
+		}
+	case FaultReset:
+	default:
+		return fmt.Errorf("unknown fault type %q (expected latency|bandwidth|reset|error|slow_body)", f.Type)
+	}
+	return nil
+}
+
+func (s Schedule) At(elapsed time.Duration) (int, int, bool) {
+	var total time.Duration
+	for _, phase := range s.Phases {
+		if phase.Duration <= 0 {
+			return 0, 0, true
+		}
+		total += phase.Duration
+	}
+
+	iteration := 0
+	if s.Loop {
+		iteration = int(elapsed / total)
+		elapsed %= total
+	} else if elapsed >= total {
+		return 0, 0, false
+	}
+
+	for i, phase := range s.Phases {
+		if elapsed < phase.Duration {
+			return i, iteration, true
+		}
+		elapsed -= phase.Duration
+	}
+	return 0, 0, false
+}
+
+func MatchRoute(pattern, path string) bool {
+	if pattern == "*" || pattern == "" {
+		return true
+	}
+
+	patternParts := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i, part := range patternParts {
+		if part == "*" && i == len(patternParts)-1 {
+			return len(pathParts) >= i
+		}
+		if i >= len(pathParts) {
+			return false
+		}
+		if strings.HasPrefix(part, ":") {
+			continue
+		}
+		if part != pathParts[i] {
+			return false
+		}
+	}
+	return len(patternParts) == len(pathParts)
+}
@@ -0,0 +1,186 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadFromPrecedence(t *testing.T) {
+	defaults := Default()
+
+	tests := []struct {
+		name       string
+		file       string
+		profile    string
+		dataset    string
+		env        map[string]string
+		flags      []string
+		key        string
+		get        func(*Config) any
+		want       any
+		wantSource string
+	}{
+		{
+			name:       "default",
+			key:        "loadTest.users",
+			get:        func(c *Config) any { return c.LoadTest.ConcurrentUsers },
+			want:       defaults.LoadTest.ConcurrentUsers,
+			wantSource: "default",
+		},
+		{
+			name:       "built-in profile over default",
+			profile:    "load",
+			key:        "loadTest.users",
+			get:        func(c *Config) any { return c.LoadTest.ConcurrentUsers },
+			want:       50,
+			wantSource: "profile load",
+		},
+		{
+			name:       "file over built-in profile",
+			file:       "profile: load\nloadTest:\n  users: 7\n",
+			key:        "loadTest.users",
+			get:        func(c *Config) any { return c.LoadTest.ConcurrentUsers },
+			want:       7,
+			wantSource: "file",
+		},
+		{
+			name:       "built-in profile fills what the file leaves unset",
+			file:       "profile: load\nloadTest:\n  users: 7\n",
+			key:        "loadTest.duration",
+			get:        func(c *Config) any { return c.LoadTest.Duration.String() },
+			want:       "10m0s",
+			wantSource: "profile load",
+		},
+		{
+			name:       "file profile over file base",
+			file:       "profile: ci\nloadTest:\n  users: 7\nprofiles:\n  ci:\n    loadTest:\n      users: 9\n",
+			key:        "loadTest.users",
+			get:        func(c *Config) any { return c.LoadTest.ConcurrentUsers },
+			want:       9,
+			wantSource: "file",
+		},
+		{
+			name:       "profile argument over the file's profile",
+			file:       "profile: smoke\n",
+			profile:    "stress",
+			key:        "loadTest.users",
+			get:        func(c *Config) any { return c.LoadTest.ConcurrentUsers },
+			want:       300,
+			wantSource: "profile stress",
+		},
+		{
+			name:       "file over built-in dataset",
+			file:       "generator:\n  users: 3\n",
+			dataset:    "tiny",
+			key:        "generator.users",
+			get:        func(c *Config) any { return c.Generator.Users },
+			want:       3,
+			wantSource: "file",
+		},
+		{
+			name:       "env over file",
+			file:       "loadTest:\n  users: 7\n",
+			env:        map[string]string{"CONCURRENT_USERS": "11"},
+			key:        "loadTest.users",
+			get:        func(c *Config) any { return c.LoadTest.ConcurrentUsers },
+			want:       11,
+			wantSource: "env CONCURRENT_USERS",
+		},
+		{
+			name:       "flag over env",
+			file:       "loadTest:\n  users: 7\n",
+			env:        map[string]string{"CONCURRENT_USERS": "11"},
+			flags:      []string{"-users", "13"},
+			key:        "loadTest.users",
+			get:        func(c *Config) any { return c.LoadTest.ConcurrentUsers },
+			want:       13,
+			wantSource: "flag -users",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isolateEnv(t, tt.env)
+
+			path := ""
+			if tt.file != "" {
+				path = filepath.Join(t.TempDir(), "loadtest.yaml")
+				if err := os.WriteFile(path, []byte(tt.file), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cfg, err := LoadFrom(path, tt.profile, tt.dataset)
+			if err != nil {
+				t.Fatalf("LoadFrom() error = %v", err)
+			}
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			cfg.BindFlag(fs, "users", "loadTest.users")
+			if err := fs.Parse(tt.flags); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := tt.get(cfg); got != tt.want {
+				t.Errorf("%s = %v, want %v", tt.key, got, tt.want)
+			}
+			if got := cfg.Source(tt.key); !strings.HasPrefix(got, tt.wantSource) {
+				t.Errorf("Source(%s) = %q, want %q", tt.key, got, tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestLoadFromErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		profile string
+		wantErr string
+	}{
+		{"unknown profile", "", "nope", "unknown profile"},
+		{"unknown key", "loadTest:\n  user: 5\n", "", "did you mean loadTest.users"},
+		{"unknown SLO endpoint", "slo:\n  endpoints:\n    \"GET /api/movies\":\n      latencyTarget: 1s\n", "", "unknown endpoint"},
+		{"bad value", "loadTest:\n  duration: soon\n", "", "loadTest.duration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isolateEnv(t, nil)
+
+			path := ""
+			if tt.file != "" {
+				path = filepath.Join(t.TempDir(), "loadtest.yaml")
+				if err := os.WriteFile(path, []byte(tt.file), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			_, err := LoadFrom(path, tt.profile, "")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadFrom() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func isolateEnv(t *testing.T, env map[string]string) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	for _, name := range []string{"LOADTEST_CONFIG", "LOADTEST_PROFILE", "GEN_DATASET"} {
+		t.Setenv(name, "")
+	}
+	for _, f := range fields {
+		if f.env != "" {
+			t.Setenv(f.env, "")
+			if f.secret {
+				t.Setenv(f.env+"_FILE", "")
+			}
+		}
+	}
+	for name, value := range env {
+		t.Setenv(name, value)
+	}
+}
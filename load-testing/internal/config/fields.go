@@ -0,0 +1,334 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"load-test/internal/secret"
+)
+
+type field struct {
+	key    string
+	env    string
+	usage  string
+	secret bool
+	ptr    func(c *Config) any
+}
+
+var fields = []field{
+	{key: "mongodb.uri", env: "MONGO_URI", secret: true, usage: "MongoDB URI (an embedded password is moved to mongodb.password)", ptr: func(c *Config) any { return &c.MongoDB.URI }},
+	{key: "mongodb.username", env: "MONGO_USERNAME", usage: "MongoDB username (overrides the one in the URI)", ptr: func(c *Config) any { return &c.MongoDB.Username }},
+	{key: "mongodb.password", env: "MONGO_PASSWORD", secret: true, usage: "MongoDB password; env:VAR and file:PATH references are resolved", ptr: func(c *Config) any { return &c.MongoDB.Password }},
+	{key: "mongodb.database", env: "MONGO_DATABASE", usage: "Database name (default: the one in the URI, else movie_recommendation)", ptr: func(c *Config) any { return &c.MongoDB.Database }},
+	{key: "mongodb.usersCollection", env: "MONGO_USERS_COLLECTION", usage: "Users collection name", ptr: func(c *Config) any { return &c.MongoDB.Collections.Users }},
+	{key: "mongodb.moviesCollection", env: "MONGO_MOVIES_COLLECTION", usage: "Movies collection name", ptr: func(c *Config) any { return &c.MongoDB.Collections.Movies }},
+	{key: "mongodb.interactionsCollection", env: "MONGO_INTERACTIONS_COLLECTION", usage: "Interactions collection name", ptr: func(c *Config) any { return &c.MongoDB.Collections.Interactions }},
+	{key: "mongodb.collectionPrefix", env: "MONGO_COLLECTION_PREFIX", usage: "Prefix for every collection name, to keep datasets isolated on a shared database (e.g. alice_)", ptr: func(c *Config) any { return &c.MongoDB.CollectionPrefix }},
+	{key: "api.url", env: "API_URL", usage: "Backend base URL", ptr: func(c *Config) any { return &c.API.URL }},
+	{key: "api.basePath", env: "API_BASE_PATH", usage: "Prefix for API routes", ptr: func(c *Config) any { return &c.API.BasePath }},
+	{key: "loadTest.mode", env: "TEST_MODE", usage: "Test mode (load|capacity|soak|controller|worker)", ptr: func(c *Config) any { return &c.LoadTest.Mode }},
+	{key: "loadTest.users", env: "CONCURRENT_USERS", usage: "Number of concurrent users", ptr: func(c *Config) any { return &c.LoadTest.ConcurrentUsers }},
+	{key: "loadTest.duration", env: "TEST_DURATION", usage: "Test duration", ptr: func(c *Config) any { return &c.LoadTest.Duration }},
+	{key: "loadTest.rampUp", env: "RAMP_UP_DURATION", usage: "Ramp-up period", ptr: func(c *Config) any { return &c.LoadTest.RampUp }},
+	{key: "loadTest.scenario", env: "SCENARIO", usage: "Scenario to run (auth|movies|recommendations|interactions|all)", ptr: func(c *Config) any { return &c.LoadTest.Scenario }},
+	{key: "generator.users", env: "GEN_USERS", usage: "Number of users to generate", ptr: func(c *Config) any { return &c.Generator.Users }},
+	{key: "generator.movies", env: "GEN_MOVIES", usage: "Number of movies to generate", ptr: func(c *Config) any { return &c.Generator.Movies }},
+	{key: "generator.interactions", env: "GEN_INTERACTIONS", usage: "Number of interactions to generate", ptr: func(c *Config) any { return &c.Generator.Interactions }},
+	{key: "generator.clearData", env: "CLEAR_DATA", usage: "Clear existing data before generating", ptr: func(c *Config) any { return &c.Generator.ClearData }},
+	{key: "generator.clearMode", env: "CLEAR_MODE", usage: "How to clear: drop whole collections, or delete only generated documents (drop|generated)", ptr: func(c *Config) any { return &c.Generator.ClearMode }},
+	{key: "generator.append", env: "GEN_APPEND", usage: "Add to the existing users and movies instead of clearing; interactions reference both old and new entities", ptr: func(c *Config) any { return &c.Generator.Append }},
+	{key: "generator.importDir", env: "GEN_IMPORT_DIR", usage: "Import a MovieLens-style dataset (movies.csv, ratings.csv, optional tags.csv) from this directory instead of generating", ptr: func(c *Config) any { return &c.Generator.ImportDir }},
+	{key: "generator.exportDir", env: "GEN_EXPORT_DIR", usage: "Also write the generated users, movies, interactions and plaintext credentials as fixture files to this directory", ptr: func(c *Config) any { return &c.Generator.ExportDir }},
+	{key: "generator.exportFormat", env: "GEN_EXPORT_FORMAT", usage: "Fixture file format (jsonl|csv|bson)", ptr: func(c *Config) any { return &c.Generator.ExportFormat }},
+	{key: "generator.exportOnly", env: "GEN_EXPORT_ONLY", usage: "Write fixtures without connecting to MongoDB", ptr: func(c *Config) any { return &c.Generator.ExportOnly }},
+	{key: "generator.coldStartUsers", env: "GEN_COLD_START_USERS", usage: "Fraction of users that get no interactions (0-1)", ptr: func(c *Config) any { return &c.Generator.ColdStartUsers }},
+	{key: "generator.powerUsers", env: "GEN_POWER_USERS", usage: "Fraction of users treated as power users (0-1)", ptr: func(c *Config) any { return &c.Generator.PowerUsers }},
+	{key: "generator.powerUserShare", env: "GEN_POWER_USER_SHARE", usage: "Fraction of interactions held by power users (0-1)", ptr: func(c *Config) any { return &c.Generator.PowerUserShare }},
+	{key: "generator.popularitySkew", env: "GEN_POPULARITY_SKEW", usage: "Movie popularity skew; 1 is uniform, higher concentrates interactions on a head of popular movies", ptr: func(c *Config) any { return &c.Generator.PopularitySkew }},
+	{key: "generator.passwordMode", env: "GEN_PASSWORD_MODE", usage: "Test user passwords: shared (generator.password for every user, also used by the load test) or random (per user, only in the credentials file; the load test cannot log in as these users)", ptr: func(c *Config) any { return &c.Generator.PasswordMode }},
+	{key: "generator.password", env: "GEN_PASSWORD", secret: true, usage: "Shared test user password, also used by the load test to register and log in; env:VAR and file:PATH references are resolved", ptr: func(c *Config) any { return &c.Generator.Password }},
+	{key: "generator.bcryptCost", env: "GEN_BCRYPT_COST", usage: "bcrypt cost for test user password hashes (match the backend to load auth realistically)", ptr: func(c *Config) any { return &c.Generator.BcryptCost }},
+	{key: "generator.credentialsFile", env: "GEN_CREDENTIALS_FILE", usage: "Write test user emails and plaintext passwords to this CSV file", ptr: func(c *Config) any { return &c.Generator.CredentialsFile }},
+	{key: "generator.workers", env: "GEN_WORKERS", usage: "Concurrent bulk insert workers", ptr: func(c *Config) any { return &c.Generator.Workers }},
+	{key: "generator.batchSize", env: "GEN_BATCH_SIZE", usage: "Documents per bulk insert", ptr: func(c *Config) any { return &c.Generator.BatchSize }},
+	{key: "generator.writeConcern", env: "GEN_WRITE_CONCERN", usage: "Write concern for inserts: majority or a node count (default: the URI's)", ptr: func(c *Config) any { return &c.Generator.WriteConcern }},
+	{key: "generator.retries", env: "GEN_RETRIES", usage: "Retries for a batch that fails with a transient error", ptr: func(c *Config) any { return &c.Generator.Retries }},
+	{key: "generator.indexes", env: "GEN_INDEXES", usage: "Create missing indexes, only verify them against the spec, or skip (create|verify|skip)", ptr: func(c *Config) any { return &c.Generator.Indexes }},
+	{key: "generator.indexSpec", env: "GEN_INDEX_SPEC", usage: "JSON index spec to compare against (default: the backend's indexes)", ptr: func(c *Config) any { return &c.Generator.IndexSpec }},
+	{key: "output.resultsDir", env: "RESULTS_DIR", usage: "Directory for results", ptr: func(c *Config) any { return &c.Output.ResultsDir }},
+	{key: "output.csv", env: "CSV_OUTPUT", usage: "Output CSV file", ptr: func(c *Config) any { return &c.Output.CSVOutput }},
+	{key: "output.report", env: "REPORT_OUTPUT", usage: "Output report file", ptr: func(c *Config) any { return &c.Output.ReportOutput }},
+	{key: "slo.latencyTarget", env: "SLO_LATENCY_TARGET", usage: "Default SLO latency target", ptr: func(c *Config) any { return &c.SLO.Default.LatencyTarget }},
+	{key: "slo.successObjective", env: "SLO_SUCCESS_OBJECTIVE", usage: "Default SLO success objective (%)", ptr: func(c *Config) any { return &c.SLO.Default.SuccessObjective }},
+	{key: "slo.window", env: "SLO_WINDOW", usage: "Default SLO burn-rate window", ptr: func(c *Config) any { return &c.SLO.Default.Window }},
+	{key: "slo.file", env: "SLO_FILE", usage: "JSON file with per-endpoint SLO overrides", ptr: func(c *Config) any { return &c.SLO.File }},
+}
+
+var profiles = map[string]map[string]string{
+	"smoke": {
+		"loadTest.users":    "2",
+		"loadTest.duration": "30s",
+		"loadTest.rampUp":   "5s",
+	},
+	"load": {
+		"loadTest.users":    "50",
+		"loadTest.duration": "10m",
+		"loadTest.rampUp":   "1m",
+	},
+	"stress": {
+		"loadTest.users":    "300",
+		"loadTest.duration": "10m",
+		"loadTest.rampUp":   "3m",
+	},
+	"soak": {
+		"loadTest.mode":     "soak",
+		"loadTest.users":    "30",
+		"loadTest.duration": "2h",
+		"loadTest.rampUp":   "5m",
+	},
+}
+
+var datasets = map[string]map[string]string{
+	"tiny": {
+		"generator.users":        "20",
+		"generator.movies":       "50",
+		"generator.interactions": "300",
+	},
+	"cold-start": {
+		"generator.users":          "5000",
+		"generator.movies":         "1000",
+		"generator.interactions":   "20000",
+		"generator.coldStartUsers": "0.6",
+	},
+	"power-users": {
+		"generator.users":          "5000",
+		"generator.movies":         "2000",
+		"generator.interactions":   "100000",
+		"generator.powerUsers":     "0.02",
+		"generator.powerUserShare": "0.8",
+	},
+	"long-tail": {
+		"generator.users":          "5000",
+		"generator.movies":         "100000",
+		"generator.interactions":   "50000",
+		"generator.popularitySkew": "3",
+	},
+	"prod-like": {
+		"generator.users":          "50000",
+		"generator.movies":         "10000",
+		"generator.interactions":   "1000000",
+		"generator.coldStartUsers": "0.15",
+		"generator.powerUsers":     "0.05",
+		"generator.powerUserShare": "0.5",
+		"generator.popularitySkew": "2",
+	},
+}
+
+func Keys() []string {
+	keys := make([]string, len(fields))
+	for i, f := range fields {
+		keys[i] = f.key
+	}
+	return keys
+}
+
+func Profiles() []string {
+	return profileNames(nil)
+}
+
+func profileNames(file *configFile) []string {
+	names := sortedKeys(profiles)
+	if file != nil {
+		for name := range file.profiles {
+			if _, exists := profiles[name]; !exists {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func Datasets() []string {
+	return datasetNames(nil)
+}
+
+func datasetNames(file *configFile) []string {
+	names := sortedKeys(datasets)
+	if file != nil {
+		for name := range file.datasets {
+			if _, exists := datasets[name]; !exists {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func lookupField(key string) *field {
+	for i := range fields {
+		if fields[i].key == key {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+func isSection(key string) bool {
+	for _, f := range fields {
+		if strings.HasPrefix(f.key, key+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Config) set(f *field, raw, source string) error {
+	if f.secret {
+		resolved, err := secret.Resolve(raw)
+		if err != nil {
+			return err
+		}
+		raw = resolved
+	}
+	if err := setValue(f.ptr(c), raw); err != nil {
+		return err
+	}
+	c.sources[f.key] = source
+
+	if f.key == "slo.file" && c.SLO.File != "" {
+		if err := c.loadSLOFile(c.SLO.File); err != nil {
+			return err
+		}
+	}
+
+	if f.key == "mongodb.uri" {
+		if uri, password := secret.SplitPassword(c.MongoDB.URI); password != "" {
+			c.MongoDB.URI = uri
+			c.MongoDB.Password = secret.Value(password)
+			c.sources["mongodb.password"] = source
+		}
+	}
+
+	c.finalize()
+	return nil
+}
+
+func setValue(ptr any, raw string) error {
+	raw = strings.TrimSpace(raw)
+	switch p := ptr.(type) {
+	case *string:
+		*p = raw
+	case *secret.Value:
+		*p = secret.Value(raw)
+	case *int:
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		*p = value
+	case *float64:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		*p = value
+	case *bool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q (expected true or false)", raw)
+		}
+		*p = value
+	case *time.Duration:
+		value, err := parseDuration(raw)
+		if err != nil {
+			return err
+		}
+		*p = value
+	default:
+		return fmt.Errorf("unsupported field type %T", ptr)
+	}
+	return nil
+}
+
+func parseDuration(raw string) (time.Duration, error) {
+	value, err := time.ParseDuration(raw)
+	if err == nil {
+		return value, nil
+	}
+	if _, numErr := strconv.ParseFloat(raw, 64); numErr == nil {
+		return 0, fmt.Errorf("invalid duration %q: missing unit (did you mean %ss?)", raw, raw)
+	}
+	return 0, fmt.Errorf("invalid duration %q (e.g. 500ms, 30s, 5m)", raw)
+}
+
+func formatValue(ptr any) string {
+	switch p := ptr.(type) {
+	case *string:
+		return *p
+	case *secret.Value:
+		return p.String()
+	case *int:
+		return strconv.Itoa(*p)
+	case *float64:
+		return strconv.FormatFloat(*p, 'g', -1, 64)
+	case *bool:
+		return strconv.FormatBool(*p)
+	case *time.Duration:
+		return p.String()
+	}
+	return ""
+}
+
+type flagValue struct {
+	config *Config
+	field  *field
+	name   string
+}
+
+func (v flagValue) String() string {
+	if v.config == nil {
+		return ""
+	}
+	return formatValue(v.field.ptr(v.config))
+}
+
+func (v flagValue) Set(raw string) error {
+	return v.config.set(v.field, raw, "flag -"+v.name)
+}
+
+func (v flagValue) IsBoolFlag() bool {
+	_, ok := v.field.ptr(&Config{}).(*bool)
+	return ok
+}
+
+func (c *Config) BindFlag(fs *flag.FlagSet, name, key string) {
+	f := lookupField(key)
+	if f == nil {
+		panic(fmt.Sprintf("config: no field %q to bind -%s to", key, name))
+	}
+	fs.Var(flagValue{config: c, field: f, name: name}, name, f.usage)
+}
+
+func FileFlags(fs *flag.FlagSet) {
+	fs.String("config", "", "YAML config file (default: $LOADTEST_CONFIG or ./"+DefaultFile+" if present)")
+	fs.String("profile", "", "Config profile (smoke|load|stress|soak or one defined in the config file)")
+}
+
+func DatasetFlag(fs *flag.FlagSet) {
+	fs.String("dataset", "", "Dataset preset ("+strings.Join(Datasets(), "|")+" or one defined in the config file)")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,187 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type configFile struct {
+	path     string
+	profile  string
+	dataset  string
+	base     *yaml.Node
+	profiles map[string]*yaml.Node
+	datasets map[string]*yaml.Node
+}
+
+func readConfigFile(path string) (*configFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	file := &configFile{
+		path:     path,
+		base:     &yaml.Node{Kind: yaml.MappingNode},
+		profiles: map[string]*yaml.Node{},
+		datasets: map[string]*yaml.Node{},
+	}
+	if len(doc.Content) == 0 {
+		return file, nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s:%d: expected a mapping at the top level", path, root.Line)
+	}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		switch key.Value {
+		case "profile":
+			if value.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("%s:%d: profile: expected a profile name", path, value.Line)
+			}
+			file.profile = value.Value
+		case "profiles":
+			if value.Kind != yaml.MappingNode {
+				return nil, fmt.Errorf("%s:%d: profiles: expected a mapping of profile names", path, value.Line)
+			}
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				name, body := value.Content[j], value.Content[j+1]
+				if body.Kind != yaml.MappingNode {
+					return nil, fmt.Errorf("%s:%d: profiles.%s: expected a mapping", path, body.Line, name.Value)
+				}
+				file.profiles[name.Value] = body
+			}
+		case "dataset":
+			if value.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("%s:%d: dataset: expected a dataset name", path, value.Line)
+			}
+			file.dataset = value.Value
+		case "datasets":
+			if value.Kind != yaml.MappingNode {
+				return nil, fmt.Errorf("%s:%d: datasets: expected a mapping of dataset names", path, value.Line)
+			}
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				name, body := value.Content[j], value.Content[j+1]
+				if body.Kind != yaml.MappingNode {
+					return nil, fmt.Errorf("%s:%d: datasets.%s: expected a mapping of generator settings", path, body.Line, name.Value)
+				}
+				file.datasets[name.Value] = body
+			}
+		default:
+			file.base.Content = append(file.base.Content, key, value)
+		}
+	}
+
+	return file, nil
+}
+
+func (f *configFile) apply(c *Config, node *yaml.Node, source string) []string {
+	return f.applyMapping(c, node, "", source)
+}
+
+func (f *configFile) applyMapping(c *Config, node *yaml.Node, prefix, source string) []string {
+	var problems []string
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, value := node.Content[i], node.Content[i+1]
+		key := keyNode.Value
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if value.Tag == "!!null" {
+			continue
+		}
+
+		if key == "slo.endpoints" {
+			if err := applyEndpoints(c, value); err != nil {
+				problems = append(problems, fmt.Sprintf("%s:%d: %s: %v", f.path, value.Line, key, err))
+			}
+			continue
+		}
+
+		if fld := lookupField(key); fld != nil {
+			if value.Kind != yaml.ScalarNode {
+				problems = append(problems, fmt.Sprintf("%s:%d: %s: expected a single value", f.path, value.Line, key))
+				continue
+			}
+			if err := c.set(fld, value.Value, source); err != nil {
+				problems = append(problems, fmt.Sprintf("%s:%d: %s: %v", f.path, value.Line, key, err))
+			}
+			continue
+		}
+
+		if isSection(key) {
+			if value.Kind != yaml.MappingNode {
+				problems = append(problems, fmt.Sprintf("%s:%d: %s: expected a mapping", f.path, value.Line, key))
+				continue
+			}
+			problems = append(problems, f.applyMapping(c, value, key, source)...)
+			continue
+		}
+
+		message := fmt.Sprintf("%s:%d: unknown key %s", f.path, keyNode.Line, key)
+		if suggestion := suggestKey(key); suggestion != "" {
+			message += fmt.Sprintf(" (did you mean %s?)", suggestion)
+		}
+		problems = append(problems, message)
+	}
+	return problems
+}
+
+func applyEndpoints(c *Config, node *yaml.Node) error {
+	endpoints := map[string]sloFileObjective{}
+	if err := node.Decode(&endpoints); err != nil {
+		return err
+	}
+	for endpoint, fileObjective := range endpoints {
+		if err := checkEndpoint(endpoint); err != nil {
+			return err
+		}
+		objective, err := fileObjective.toObjective()
+		if err != nil {
+			return fmt.Errorf("%s: %w", endpoint, err)
+		}
+		c.SLO.Endpoints[endpoint] = objective
+	}
+	return nil
+}
+
+func suggestKey(key string) string {
+	best, bestDistance := "", 4
+	for _, f := range fields {
+		if distance := editDistance(strings.ToLower(key), strings.ToLower(f.key)); distance < bestDistance {
+			best, bestDistance = f.key, distance
+		}
+	}
+	return best
+}
+
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
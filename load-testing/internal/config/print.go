@@ -0,0 +1,75 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"load-test/internal/secret"
+)
+
+func (c *Config) Print(w io.Writer) {
+	if c.File != "" {
+		fmt.Fprintf(w, "# config file: %s\n", c.File)
+	}
+	if c.Profile != "" {
+		fmt.Fprintf(w, "# profile: %s\n", c.Profile)
+	}
+	if c.Dataset != "" {
+		fmt.Fprintf(w, "# dataset: %s\n", c.Dataset)
+	}
+
+	type line struct{ text, source string }
+	var lines []line
+	section := ""
+	for _, f := range fields {
+		parts := strings.SplitN(f.key, ".", 2)
+		if parts[0] != section {
+			section = parts[0]
+			lines = append(lines, line{text: section + ":"})
+		}
+
+		ptr := f.ptr(c)
+		value := formatValue(ptr)
+		switch ptr.(type) {
+		case *string:
+			if f.secret {
+				value = secret.MaskURI(value)
+			}
+			value = strconv.Quote(value)
+		case *secret.Value:
+			value = strconv.Quote(value)
+		}
+		lines = append(lines, line{text: fmt.Sprintf("  %s: %s", parts[1], value), source: c.Source(f.key)})
+	}
+
+	width := 0
+	for _, l := range lines {
+		width = max(width, len(l.text))
+	}
+	for _, l := range lines {
+		if l.source == "" {
+			fmt.Fprintln(w, l.text)
+			continue
+		}
+		fmt.Fprintf(w, "%-*s  # %s\n", width, l.text, l.source)
+	}
+
+	if len(c.SLO.Endpoints) > 0 {
+		fmt.Fprintf(w, "  endpoints:\n")
+		for _, endpoint := range sortedKeys(c.SLO.Endpoints) {
+			objective := c.SLO.Endpoints[endpoint]
+			fmt.Fprintf(w, "    %s:\n", strconv.Quote(endpoint))
+			if objective.LatencyTarget > 0 {
+				fmt.Fprintf(w, "      latencyTarget: %s\n", objective.LatencyTarget)
+			}
+			if objective.SuccessObjective > 0 {
+				fmt.Fprintf(w, "      successObjective: %g\n", objective.SuccessObjective)
+			}
+			if objective.Window > 0 {
+				fmt.Fprintf(w, "      window: %s\n", objective.Window)
+			}
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Validate checks the keys under the given sections (a section name like
+// "mongodb" or a single key like "generator.password"); with none it checks
+// everything.
+func (c *Config) Validate(sections ...string) error {
+	var problems []string
+	check := func(key string, ok bool, format string, args ...any) {
+		if !ok && inSections(key, sections) {
+			problems = append(problems, fmt.Sprintf("%s: %s (set by %s)", key, fmt.Sprintf(format, args...), c.Source(key)))
+		}
+	}
+
+	check("mongodb.uri", strings.HasPrefix(c.MongoDB.URI, "mongodb://") || strings.HasPrefix(c.MongoDB.URI, "mongodb+srv://"),
+		"must start with mongodb:// or mongodb+srv://")
+	check("mongodb.password", c.MongoDB.Password == "" || strings.Contains(c.MongoDB.ConnectionURI(), "@"),
+		"is set but there is no username (set mongodb.username or put one in the URI)")
+	check("mongodb.database", validDatabaseName(c.MongoDB.DatabaseName()),
+		"%q is not a valid database name", c.MongoDB.DatabaseName())
+	check("mongodb.collectionPrefix", !strings.ContainsAny(c.MongoDB.CollectionPrefix, "$\x00"),
+		"must not contain $ or NUL, got %q", c.MongoDB.CollectionPrefix)
+	names := c.MongoDB.CollectionNames()
+	check("mongodb.usersCollection", validCollectionName(names.Users), "%q is not a valid collection name", names.Users)
+	check("mongodb.moviesCollection", validCollectionName(names.Movies), "%q is not a valid collection name", names.Movies)
+	check("mongodb.interactionsCollection", validCollectionName(names.Interactions), "%q is not a valid collection name", names.Interactions)
+	check("mongodb.collectionPrefix", names.Users != names.Movies && names.Movies != names.Interactions && names.Users != names.Interactions,
+		"collection names must be distinct, got %s", strings.Join(names.All(), ", "))
+
+	apiURL, err := url.Parse(c.API.URL)
+	check("api.url", err == nil && (apiURL.Scheme == "http" || apiURL.Scheme == "https") && apiURL.Host != "",
+		"must be an absolute http(s) URL, got %q", c.API.URL)
+	check("api.basePath", c.API.BasePath == "" || strings.HasPrefix(c.API.BasePath, "/"),
+		"must be empty or start with /, got %q", c.API.BasePath)
+
+	lt := c.LoadTest
+	check("loadTest.mode", slices.Contains(Modes, lt.Mode), "must be one of %s, got %q", strings.Join(Modes, "|"), lt.Mode)
+	check("loadTest.users", lt.ConcurrentUsers > 0, "must be positive, got %d", lt.ConcurrentUsers)
+	check("loadTest.duration", lt.Duration > 0, "must be positive, got %s", lt.Duration)
+	check("loadTest.rampUp", lt.RampUp >= 0, "must not be negative, got %s", lt.RampUp)
+	check("loadTest.rampUp", lt.RampUp <= lt.Duration, "ramp-up %s is longer than the test duration %s", lt.RampUp, lt.Duration)
+	check("loadTest.scenario", slices.Contains(Scenarios, lt.Scenario), "must be one of %s, got %q", strings.Join(Scenarios, "|"), lt.Scenario)
+
+	gen := c.Generator
+	check("generator.users", gen.Users >= 0 && gen.Users <= math.MaxUint32, "must be in [0, %d], got %d", uint32(math.MaxUint32), gen.Users)
+	check("generator.movies", gen.Movies >= 0 && gen.Movies <= math.MaxUint32, "must be in [0, %d], got %d", uint32(math.MaxUint32), gen.Movies)
+	check("generator.interactions", gen.Interactions >= 0, "must not be negative, got %d", gen.Interactions)
+	check("generator.clearMode", slices.Contains(ClearModes, gen.ClearMode), "must be one of %s, got %q", strings.Join(ClearModes, "|"), gen.ClearMode)
+	check("generator.workers", gen.Workers > 0, "must be positive, got %d", gen.Workers)
+	check("generator.batchSize", gen.BatchSize > 0 && gen.BatchSize <= 100000, "must be in [1, 100000], got %d", gen.BatchSize)
+	check("generator.writeConcern", validWriteConcern(gen.WriteConcern), "must be majority or a node count, got %q", gen.WriteConcern)
+	check("generator.retries", gen.Retries >= 0, "must not be negative, got %d", gen.Retries)
+	check("generator.indexes", slices.Contains(IndexModes, gen.Indexes), "must be one of %s, got %q", strings.Join(IndexModes, "|"), gen.Indexes)
+	check("generator.coldStartUsers", gen.ColdStartUsers >= 0 && gen.ColdStartUsers <= 1, "must be in [0, 1], got %g", gen.ColdStartUsers)
+	check("generator.powerUsers", gen.PowerUsers >= 0 && gen.PowerUsers <= 1, "must be in [0, 1], got %g", gen.PowerUsers)
+	check("generator.powerUsers", gen.ColdStartUsers+gen.PowerUsers <= 1, "cold-start and power users together exceed all users (%g + %g)", gen.ColdStartUsers, gen.PowerUsers)
+	check("generator.powerUserShare", gen.PowerUserShare >= 0 && gen.PowerUserShare <= 1, "must be in [0, 1], got %g", gen.PowerUserShare)
+	check("generator.popularitySkew", gen.PopularitySkew >= 1, "must be at least 1 (uniform), got %g", gen.PopularitySkew)
+	check("generator.passwordMode", slices.Contains(PasswordModes, gen.PasswordMode), "must be one of %s, got %q", strings.Join(PasswordModes, "|"), gen.PasswordMode)
+	check("generator.passwordMode", gen.PasswordMode != PasswordRandom || gen.CredentialsFile != "" || gen.ExportDir != "",
+		"random passwords need generator.credentialsFile or generator.exportDir, otherwise they are lost")
+	check("generator.password", gen.PasswordMode != PasswordShared || (gen.Password != "" && len(gen.Password.Reveal()) <= 72), "must be 1-72 bytes (bcrypt limit)")
+	check("generator.bcryptCost", gen.BcryptCost >= 4 && gen.BcryptCost <= 31, "must be in [4, 31], got %d", gen.BcryptCost)
+	check("generator.importDir", gen.ImportDir == "" || !gen.Append, "cannot be combined with generator.append")
+	check("generator.exportFormat", slices.Contains(ExportFormats, gen.ExportFormat), "must be one of %s, got %q", strings.Join(ExportFormats, "|"), gen.ExportFormat)
+	check("generator.exportOnly", !gen.ExportOnly || gen.ExportDir != "", "needs generator.exportDir")
+	check("generator.exportOnly", !gen.ExportOnly || !gen.Append, "cannot be combined with generator.append")
+	check("generator.interactions", gen.Interactions == 0 || gen.Append || gen.ImportDir != "" || (gen.Users > 0 && gen.Movies > 0),
+		"needs at least one user and one movie to generate %d interactions", gen.Interactions)
+
+	check("output.resultsDir", c.Output.ResultsDir != "", "must not be empty")
+	check("output.csv", c.Output.CSVOutput != "", "must not be empty")
+	check("output.report", c.Output.ReportOutput != "", "must not be empty")
+
+	slo := c.SLO.Default
+	check("slo.latencyTarget", slo.LatencyTarget > 0, "must be positive, got %s", slo.LatencyTarget)
+	check("slo.successObjective", slo.SuccessObjective > 0 && slo.SuccessObjective < 100,
+		"must be in (0, 100), got %g", slo.SuccessObjective)
+	check("slo.window", slo.Window > 0, "must be positive, got %s", slo.Window)
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid configuration:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
+
+func inSections(key string, sections []string) bool {
+	if len(sections) == 0 {
+		return true
+	}
+	for _, section := range sections {
+		if key == section || strings.HasPrefix(key, section+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func validDatabaseName(name string) bool {
+	return name != "" && len(name) < 64 && !strings.ContainsAny(name, "/\\. \"$*<>:|?\x00")
+}
+
+func validCollectionName(name string) bool {
+	return name != "" && !strings.ContainsAny(name, "$\x00") && !strings.HasPrefix(name, "system.")
+}
+
+func validWriteConcern(w string) bool {
+	if w == "" || w == "majority" {
+		return true
+	}
+	n, err := strconv.Atoi(w)
+	return err == nil && n >= 0
+}
@@ -0,0 +1,368 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+const duplicateKeyCode = 11000
+
+type Collections struct {
+	Users        *mongo.Collection
+	Movies       *mongo.Collection
+	Interactions *mongo.Collection
+}
+
+func NewCollections(db *mongo.Database, names map[string]string) Collections {
+	if db == nil {
+		return Collections{}
+	}
+	return Collections{
+		Users:        db.Collection(names["users"]),
+		Movies:       db.Collection(names["movies"]),
+		Interactions: db.Collection(names["interactions"]),
+	}
+}
+
+func (c Collections) byLabel(label string) *mongo.Collection {
+	switch label {
+	case "users":
+		return c.Users
+	case "movies":
+		return c.Movies
+	case "interactions":
+		return c.Interactions
+	}
+	return nil
+}
+
+type Options struct {
+	Generation   string
+	IDs          IDSpace
+	NewReleases  bool
+	Distribution Distribution
+	Passwords    PasswordConfig
+	Bulk         BulkConfig
+}
+
+type Distribution struct {
+	ColdStartUsers float64 `json:"coldStartUsers"`
+	PowerUsers     float64 `json:"powerUsers"`
+	PowerUserShare float64 `json:"powerUserShare"`
+	PopularitySkew float64 `json:"popularitySkew"`
+}
+
+type BulkConfig struct {
+	Workers      int
+	BatchSize    int
+	WriteConcern string
+	Retries      int
+	RetryBackoff time.Duration
+	Export       *FixtureWriter
+	Credentials  *CredentialsWriter
+}
+
+func DefaultBulkConfig() BulkConfig {
+	return BulkConfig{
+		Workers:      4,
+		BatchSize:    1000,
+		Retries:      3,
+		RetryBackoff: 200 * time.Millisecond,
+	}
+}
+
+func (c BulkConfig) writeConcern() (*writeconcern.WriteConcern, error) {
+	switch c.WriteConcern {
+	case "":
+		return nil, nil
+	case "majority":
+		return writeconcern.Majority(), nil
+	}
+
+	w, err := strconv.Atoi(c.WriteConcern)
+	if err != nil || w < 0 {
+		return nil, fmt.Errorf("invalid write concern %q (expected majority or a number of nodes)", c.WriteConcern)
+	}
+	if w == 0 {
+		return writeconcern.Unacknowledged(), nil
+	}
+	return &writeconcern.WriteConcern{W: w}, nil
+}
+
+func BulkInsert(ctx context.Context, collection *mongo.Collection, cfg BulkConfig, label string, count int, fakers func(batch int) *gofakeit.Faker, build func(f *gofakeit.Faker, i int) (interface{}, error)) error {
+	var next atomic.Int64
+	return bulkInsert(ctx, collection, cfg, label, count, func() ([]interface{}, error) {
+		b := int(next.Add(1)) - 1
+		start := b * cfg.BatchSize
+		if start >= count {
+			return nil, nil
+		}
+		end := min(start+cfg.BatchSize, count)
+
+		f := fakers(b)
+		batch := make([]interface{}, 0, end-start)
+		for i := start; i < end; i++ {
+			doc, err := build(f, i)
+			if err != nil {
+				return nil, fmt.Errorf("failed to build %s: %w", label, err)
+			}
+			batch = append(batch, doc)
+		}
+		return batch, nil
+	})
+}
+
+func BulkInsertGroups(ctx context.Context, collection *mongo.Collection, cfg BulkConfig, label string, count int, build func() ([]interface{}, bool)) error {
+	var reserved atomic.Int64
+	return bulkInsert(ctx, collection, cfg, label, count, func() ([]interface{}, error) {
+		batch := make([]interface{}, 0, cfg.BatchSize)
+		for len(batch) < cfg.BatchSize {
+			group, ok := build()
+			if !ok {
+				break
+			}
+			end := int(reserved.Add(int64(len(group))))
+			start := end - len(group)
+			if start >= count {
+				break
+			}
+			if end > count {
+				group = group[:count-start]
+			}
+			batch = append(batch, group...)
+			if end >= count {
+				break
+			}
+		}
+		if len(batch) == 0 {
+			return nil, nil
+		}
+		return batch, nil
+	})
+}
+
+func bulkInsert(ctx context.Context, collection *mongo.Collection, cfg BulkConfig, label string, count int, nextBatch func() ([]interface{}, error)) error {
+	if count <= 0 {
+		return nil
+	}
+
+	wc, err := cfg.writeConcern()
+	if err != nil {
+		return err
+	}
+	if wc != nil && collection != nil {
+		if collection, err = collection.Clone(options.Collection().SetWriteConcern(wc)); err != nil {
+			return err
+		}
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var firstErr error
+	var errOnce sync.Once
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	batches := make(chan []interface{}, cfg.Workers*2)
+	var producers sync.WaitGroup
+	for p := 0; p < runtime.GOMAXPROCS(0); p++ {
+		producers.Add(1)
+		go func() {
+			defer producers.Done()
+			for {
+				batch, err := nextBatch()
+				if err != nil {
+					fail(err)
+					return
+				}
+				if batch == nil {
+					return
+				}
+
+				select {
+				case batches <- batch:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		producers.Wait()
+		close(batches)
+	}()
+
+	progress := newProgress(label, count)
+	stopProgress := progress.report()
+
+	var writers sync.WaitGroup
+	for w := 0; w < cfg.Workers; w++ {
+		writers.Add(1)
+		go func() {
+			defer writers.Done()
+			for batch := range batches {
+				if ctx.Err() != nil {
+					continue
+				}
+				if collection != nil {
+					if err := insertWithRetry(ctx, collection, batch, cfg); err != nil {
+						fail(fmt.Errorf("failed to insert %s: %w", label, err))
+						continue
+					}
+				}
+				if cfg.Export != nil {
+					if err := cfg.Export.Write(label, batch); err != nil {
+						fail(err)
+						continue
+					}
+				}
+				if cfg.Credentials != nil {
+					if err := cfg.Credentials.Write(batch); err != nil {
+						fail(err)
+						continue
+					}
+				}
+				progress.add(len(batch))
+			}
+		}()
+	}
+	writers.Wait()
+	stopProgress()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	if err := parent.Err(); err != nil {
+		return err
+	}
+	progress.finish()
+	return nil
+}
+
+func insertWithRetry(ctx context.Context, collection *mongo.Collection, batch []interface{}, cfg BulkConfig) error {
+	backoff := cfg.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		_, err := collection.InsertMany(ctx, batch, options.InsertMany().SetOrdered(false))
+		if err == nil || errors.Is(err, mongo.ErrUnacknowledgedWrite) {
+			return nil
+		}
+		if attempt > 0 && onlyDuplicates(err) {
+			return nil
+		}
+		if attempt >= cfg.Retries || !isTransient(err) {
+			return err
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		backoff *= 2
+	}
+}
+
+func isTransient(err error) bool {
+	if mongo.IsNetworkError(err) || mongo.IsTimeout(err) {
+		return true
+	}
+
+	var labeled mongo.ServerError
+	if errors.As(err, &labeled) && (labeled.HasErrorLabel("RetryableWriteError") || labeled.HasErrorLabel("TransientTransactionError")) {
+		return true
+	}
+
+	var bulkErr mongo.BulkWriteException
+	return errors.As(err, &bulkErr) && bulkErr.WriteConcernError != nil
+}
+
+func onlyDuplicates(err error) bool {
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil || len(bulkErr.WriteErrors) == 0 {
+		return false
+	}
+	for _, writeErr := range bulkErr.WriteErrors {
+		if writeErr.Code != duplicateKeyCode {
+			return false
+		}
+	}
+	return true
+}
+
+type progress struct {
+	label string
+	total int
+	done  atomic.Int64
+	start time.Time
+}
+
+func newProgress(label string, total int) *progress {
+	return &progress{label: label, total: total, start: time.Now()}
+}
+
+func (p *progress) add(n int) {
+	p.done.Add(int64(n))
+}
+
+func (p *progress) rate() float64 {
+	elapsed := time.Since(p.start).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(p.done.Load()) / elapsed
+}
+
+func (p *progress) print() {
+	done := p.done.Load()
+	rate := p.rate()
+	eta := "--"
+	if rate > 0 {
+		remaining := time.Duration(float64(int64(p.total)-done) / rate * float64(time.Second))
+		eta = remaining.Round(time.Second).String()
+	}
+	fmt.Printf("\r   Progress: %d/%d (%.0f docs/s, ETA %s)   ", done, p.total, rate, eta)
+}
+
+func (p *progress) report() func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(500 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.print()
+			case <-done:
+				p.print()
+				return
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+func (p *progress) finish() {
+	fmt.Printf("\n✅ Created %d %s in %s (%.0f docs/s)\n", p.done.Load(), p.label, time.Since(p.start).Round(time.Millisecond), p.rate())
+}
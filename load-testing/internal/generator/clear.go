@@ -0,0 +1,72 @@
+package generator
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const GenerationField = "loadTestGeneration"
+
+type CollectionCount struct {
+	Name      string
+	Total     int64
+	Generated int64
+}
+
+func NewGenerationID() string {
+	suffix := make([]byte, 3)
+	rand.Read(suffix)
+	return time.Now().UTC().Format("20060102-150405") + "-" + hex.EncodeToString(suffix)
+}
+
+func GenerationFilter(generation string) bson.M {
+	if generation == "" {
+		return bson.M{GenerationField: bson.M{"$exists": true}}
+	}
+	return bson.M{GenerationField: generation}
+}
+
+func CountDocuments(ctx context.Context, db *mongo.Database, names []string, generation string) ([]CollectionCount, error) {
+	counts := make([]CollectionCount, 0, len(names))
+	for _, name := range names {
+		collection := db.Collection(name)
+
+		total, err := collection.EstimatedDocumentCount(ctx)
+		if err != nil {
+			return nil, err
+		}
+		generated, err := collection.CountDocuments(ctx, GenerationFilter(generation))
+		if err != nil {
+			return nil, err
+		}
+
+		counts = append(counts, CollectionCount{Name: name, Total: total, Generated: generated})
+	}
+	return counts, nil
+}
+
+func DropCollections(ctx context.Context, db *mongo.Database, names []string) error {
+	for _, name := range names {
+		if err := db.Collection(name).Drop(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func DeleteGenerated(ctx context.Context, db *mongo.Database, names []string, generation string) (int64, error) {
+	var deleted int64
+	for _, name := range names {
+		result, err := db.Collection(name).DeleteMany(ctx, GenerationFilter(generation))
+		if err != nil {
+			return deleted, err
+		}
+		deleted += result.DeletedCount
+	}
+	return deleted, nil
+}
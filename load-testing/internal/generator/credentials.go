@@ -0,0 +1,70 @@
+package generator
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"load-test/internal/models"
+)
+
+type CredentialsWriter struct {
+	path string
+	mu   sync.Mutex
+	out  *fixtureFile
+}
+
+func NewCredentialsWriter(path string) (*CredentialsWriter, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create credentials directory: %w", err)
+	}
+	out, err := createFixtureFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := out.file.Chmod(0o600); err != nil {
+		out.file.Close()
+		return nil, fmt.Errorf("failed to restrict credentials file: %w", err)
+	}
+	out.csv = csv.NewWriter(out.buffer)
+	out.csv.Write([]string{"email", "username", "password"})
+	return &CredentialsWriter{path: path, out: out}, nil
+}
+
+func (w *CredentialsWriter) Path() string {
+	return w.path
+}
+
+func (w *CredentialsWriter) Write(docs []interface{}) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, doc := range docs {
+		user, ok := doc.(models.User)
+		if !ok || user.PlainPassword == "" {
+			continue
+		}
+		if err := w.out.csv.Write([]string{user.Email, user.Username, user.PlainPassword}); err != nil {
+			return fmt.Errorf("failed to write credentials: %w", err)
+		}
+		w.out.count++
+	}
+	return nil
+}
+
+func (w *CredentialsWriter) Count() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.out.count
+}
+
+func (w *CredentialsWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.out.csv.Flush()
+	return errors.Join(w.out.csv.Error(), w.out.buffer.Flush(), w.out.file.Close())
+}
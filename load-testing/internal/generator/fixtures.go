@@ -0,0 +1,481 @@
+package generator
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"load-test/internal/config"
+	"load-test/internal/models"
+)
+
+const (
+	CredentialsFile = "credentials.csv"
+
+	listSeparator = "|"
+)
+
+var FixtureLabels = []string{"users", "movies", "interactions"}
+
+type csvColumn struct {
+	path string
+	kind bsontype.Type
+}
+
+var csvColumns = map[string][]csvColumn{
+	"users": {
+		{"_id", bsontype.ObjectID},
+		{"email", bsontype.String},
+		{"password", bsontype.String},
+		{"username", bsontype.String},
+		{"firstName", bsontype.String},
+		{"lastName", bsontype.String},
+		{"preferences.favoriteGenres", bsontype.Array},
+		{"preferences.dislikedGenres", bsontype.Array},
+		{"createdAt", bsontype.DateTime},
+		{"updatedAt", bsontype.DateTime},
+		{GenerationField, bsontype.String},
+	},
+	"movies": {
+		{"_id", bsontype.ObjectID},
+		{"title", bsontype.String},
+		{"description", bsontype.String},
+		{"genres", bsontype.Array},
+		{"director", bsontype.String},
+		{"cast", bsontype.Array},
+		{"releaseYear", bsontype.Int32},
+		{"duration", bsontype.Int32},
+		{"rating", bsontype.Double},
+		{"posterUrl", bsontype.String},
+		{"trailerUrl", bsontype.String},
+		{"price", bsontype.Double},
+		{"createdAt", bsontype.DateTime},
+		{"updatedAt", bsontype.DateTime},
+		{GenerationField, bsontype.String},
+	},
+	"interactions": {
+		{"_id", bsontype.ObjectID},
+		{"userId", bsontype.ObjectID},
+		{"movieId", bsontype.ObjectID},
+		{"type", bsontype.String},
+		{"rating", bsontype.Int32},
+		{"timestamp", bsontype.DateTime},
+		{GenerationField, bsontype.String},
+	},
+}
+
+type fixtureFile struct {
+	file   *os.File
+	buffer *bufio.Writer
+	csv    *csv.Writer
+	count  int
+}
+
+type FixtureWriter struct {
+	dir         string
+	format      string
+	mu          sync.Mutex
+	files       map[string]*fixtureFile
+	credentials *CredentialsWriter
+}
+
+func NewFixtureWriter(dir, format string) (*FixtureWriter, error) {
+	if _, ok := fixtureFormat(format); !ok {
+		return nil, fmt.Errorf("unknown fixture format %q (expected %s)", format, strings.Join(config.ExportFormats, "|"))
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create fixture directory: %w", err)
+	}
+	return &FixtureWriter{dir: dir, format: format, files: make(map[string]*fixtureFile)}, nil
+}
+
+func fixtureFormat(format string) (string, bool) {
+	for _, known := range config.ExportFormats {
+		if format == known {
+			return known, true
+		}
+	}
+	return "", false
+}
+
+func (w *FixtureWriter) Dir() string {
+	return w.dir
+}
+
+func (w *FixtureWriter) Path(label string) string {
+	return filepath.Join(w.dir, label+"."+w.format)
+}
+
+func (w *FixtureWriter) Write(label string, docs []interface{}) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	out, err := w.open(label)
+	if err != nil {
+		return err
+	}
+
+	for _, doc := range docs {
+		raw, err := bson.Marshal(doc)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s fixture: %w", label, err)
+		}
+
+		switch w.format {
+		case config.FixtureBSON:
+			_, err = out.buffer.Write(raw)
+		case config.FixtureJSONL:
+			var line []byte
+			if line, err = bson.MarshalExtJSON(bson.Raw(raw), false, false); err == nil {
+				line = append(line, '\n')
+				_, err = out.buffer.Write(line)
+			}
+		case config.FixtureCSV:
+			err = out.csv.Write(csvRecord(csvColumns[label], bson.Raw(raw)))
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write %s fixture: %w", label, err)
+		}
+		out.count++
+
+		if user, ok := doc.(models.User); ok && user.PlainPassword != "" {
+			if w.credentials == nil {
+				if w.credentials, err = NewCredentialsWriter(filepath.Join(w.dir, CredentialsFile)); err != nil {
+					return err
+				}
+			}
+			if err := w.credentials.Write([]interface{}{user}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *FixtureWriter) open(label string) (*fixtureFile, error) {
+	if out, ok := w.files[label]; ok {
+		return out, nil
+	}
+	if _, ok := csvColumns[label]; !ok {
+		return nil, fmt.Errorf("unknown fixture collection %q", label)
+	}
+
+	out, err := createFixtureFile(filepath.Join(w.dir, label+"."+w.format))
+	if err != nil {
+		return nil, err
+	}
+	if w.format == config.FixtureCSV {
+		out.csv = csv.NewWriter(out.buffer)
+		header := make([]string, 0, len(csvColumns[label]))
+		for _, column := range csvColumns[label] {
+			header = append(header, column.path)
+		}
+		out.csv.Write(header)
+	}
+	w.files[label] = out
+	return out, nil
+}
+
+func createFixtureFile(path string) (*fixtureFile, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fixture file: %w", err)
+	}
+	return &fixtureFile{file: file, buffer: bufio.NewWriterSize(file, 1<<20)}, nil
+}
+
+func (w *FixtureWriter) Counts() map[string]int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	counts := make(map[string]int, len(w.files)+1)
+	for label, out := range w.files {
+		counts[label] = out.count
+	}
+	if w.credentials != nil {
+		counts["credentials"] = w.credentials.Count()
+	}
+	return counts
+}
+
+func (w *FixtureWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var errs []error
+	if w.credentials != nil {
+		errs = append(errs, w.credentials.Close())
+	}
+	for _, out := range w.files {
+		if out.csv != nil {
+			out.csv.Flush()
+			errs = append(errs, out.csv.Error())
+		}
+		errs = append(errs, out.buffer.Flush(), out.file.Close())
+	}
+	return errors.Join(errs...)
+}
+
+func csvRecord(columns []csvColumn, doc bson.Raw) []string {
+	record := make([]string, len(columns))
+	for i, column := range columns {
+		value, err := doc.LookupErr(strings.Split(column.path, ".")...)
+		if err != nil {
+			continue
+		}
+		record[i] = formatCSVValue(value)
+	}
+	return record
+}
+
+func formatCSVValue(value bson.RawValue) string {
+	switch value.Type {
+	case bsontype.ObjectID:
+		return value.ObjectID().Hex()
+	case bsontype.String:
+		return value.StringValue()
+	case bsontype.Int32:
+		return strconv.Itoa(int(value.Int32()))
+	case bsontype.Int64:
+		return strconv.FormatInt(value.Int64(), 10)
+	case bsontype.Double:
+		return strconv.FormatFloat(value.Double(), 'f', -1, 64)
+	case bsontype.DateTime:
+		return value.Time().UTC().Format(time.RFC3339Nano)
+	case bsontype.Array:
+		values, _ := value.Array().Values()
+		items := make([]string, len(values))
+		for i, item := range values {
+			items[i] = formatCSVValue(item)
+		}
+		return strings.Join(items, listSeparator)
+	case bsontype.Null:
+		return ""
+	default:
+		return value.String()
+	}
+}
+
+type FixtureReader struct {
+	Path    string
+	Format  string
+	file    *os.File
+	buffer  *bufio.Reader
+	csv     *csv.Reader
+	columns []csvColumn
+	line    int
+}
+
+func FindFixture(dir, label string) (string, string, error) {
+	var found []string
+	for _, format := range config.ExportFormats {
+		if _, err := os.Stat(filepath.Join(dir, label+"."+format)); err == nil {
+			found = append(found, format)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return "", "", fmt.Errorf("no %s fixture in %s: %w", label, dir, os.ErrNotExist)
+	case 1:
+		return filepath.Join(dir, label+"."+found[0]), found[0], nil
+	default:
+		return "", "", fmt.Errorf("%s has %s fixtures in several formats (%s); remove all but one", dir, label, strings.Join(found, ", "))
+	}
+}
+
+func OpenFixture(dir, label string) (*FixtureReader, error) {
+	path, format, err := FindFixture(dir, label)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	reader := &FixtureReader{Path: path, Format: format, file: file, buffer: bufio.NewReaderSize(file, 1<<20)}
+
+	if format == config.FixtureCSV {
+		reader.csv = csv.NewReader(reader.buffer)
+		header, err := reader.csv.Read()
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("%s: failed to read header: %w", path, err)
+		}
+		known := make(map[string]csvColumn)
+		for _, column := range csvColumns[label] {
+			known[column.path] = column
+		}
+		for _, name := range header {
+			column, ok := known[name]
+			if !ok {
+				file.Close()
+				return nil, fmt.Errorf("%s: unknown column %q", path, name)
+			}
+			reader.columns = append(reader.columns, column)
+		}
+		reader.line = 1
+	}
+	return reader, nil
+}
+
+func (r *FixtureReader) Next() (bson.Raw, error) {
+	r.line++
+	raw, err := r.next()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%s:%d: %w", r.Path, r.line, err)
+	}
+	return raw, err
+}
+
+func (r *FixtureReader) next() (bson.Raw, error) {
+	switch r.Format {
+	case config.FixtureBSON:
+		var size [4]byte
+		if _, err := io.ReadFull(r.buffer, size[:]); err != nil {
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil, fmt.Errorf("truncated document")
+			}
+			return nil, err
+		}
+		length := int(binary.LittleEndian.Uint32(size[:]))
+		if length < 5 {
+			return nil, fmt.Errorf("invalid document length %d", length)
+		}
+		doc := make([]byte, length)
+		copy(doc, size[:])
+		if _, err := io.ReadFull(r.buffer, doc[4:]); err != nil {
+			return nil, fmt.Errorf("truncated document")
+		}
+		return bson.Raw(doc), bson.Raw(doc).Validate()
+
+	case config.FixtureJSONL:
+		for {
+			line, err := r.buffer.ReadBytes('\n')
+			if len(strings.TrimSpace(string(line))) == 0 {
+				if err != nil {
+					return nil, err
+				}
+				r.line++
+				continue
+			}
+			var doc bson.D
+			if err := bson.UnmarshalExtJSON(line, false, &doc); err != nil {
+				return nil, err
+			}
+			return bson.Marshal(doc)
+		}
+
+	default:
+		record, err := r.csv.Read()
+		if err != nil {
+			return nil, err
+		}
+		doc, err := csvDocument(r.columns, record)
+		if err != nil {
+			return nil, err
+		}
+		return bson.Marshal(doc)
+	}
+}
+
+func (r *FixtureReader) Close() error {
+	return r.file.Close()
+}
+
+func csvDocument(columns []csvColumn, record []string) (bson.D, error) {
+	var doc bson.D
+	for i, column := range columns {
+		raw := record[i]
+		if raw == "" && column.kind != bsontype.Array {
+			continue
+		}
+
+		var value interface{}
+		switch column.kind {
+		case bsontype.ObjectID:
+			id, err := primitive.ObjectIDFromHex(raw)
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid ObjectID %q", column.path, raw)
+			}
+			value = id
+		case bsontype.Int32:
+			n, err := strconv.Atoi(raw)
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid integer %q", column.path, raw)
+			}
+			value = n
+		case bsontype.Double:
+			f, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid number %q", column.path, raw)
+			}
+			value = f
+		case bsontype.DateTime:
+			t, err := time.Parse(time.RFC3339Nano, raw)
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid time %q", column.path, raw)
+			}
+			value = t
+		case bsontype.Array:
+			items := []string{}
+			if raw != "" {
+				items = strings.Split(raw, listSeparator)
+			}
+			value = items
+		default:
+			value = raw
+		}
+		doc = setPath(doc, strings.Split(column.path, "."), value)
+	}
+	return doc, nil
+}
+
+func setPath(doc bson.D, path []string, value interface{}) bson.D {
+	if len(path) == 1 {
+		return append(doc, bson.E{Key: path[0], Value: value})
+	}
+	for i := range doc {
+		if doc[i].Key == path[0] {
+			if nested, ok := doc[i].Value.(bson.D); ok {
+				doc[i].Value = setPath(nested, path[1:], value)
+				return doc
+			}
+		}
+	}
+	return append(doc, bson.E{Key: path[0], Value: setPath(nil, path[1:], value)})
+}
+
+func ReadCredentials(dir string) (map[string]string, error) {
+	file, err := os.Open(filepath.Join(dir, CredentialsFile))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", CredentialsFile, err)
+	}
+	credentials := make(map[string]string, len(records))
+	for i, record := range records {
+		if i == 0 || len(record) < 3 {
+			continue
+		}
+		credentials[strings.ToLower(record[0])] = record[2]
+	}
+	return credentials, nil
+}
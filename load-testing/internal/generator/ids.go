@@ -0,0 +1,99 @@
+package generator
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	kindUser        byte = 'u'
+	kindMovie       byte = 'm'
+	kindInteraction byte = 'i'
+	kindUnit        byte = 'x'
+	kindClass       byte = 'c'
+	kindQuota       byte = 'q'
+)
+
+type IDSpace struct {
+	Seed  int64
+	Epoch time.Time
+
+	UserBase        int
+	MovieBase       int
+	InteractionBase int
+}
+
+func NewIDSpace(seed int64, epoch time.Time) IDSpace {
+	return IDSpace{Seed: seed, Epoch: epoch.UTC().Truncate(time.Second)}
+}
+
+func (s IDSpace) After(users, movies, interactions int) IDSpace {
+	s.UserBase, s.MovieBase, s.InteractionBase = users, movies, interactions
+	return s
+}
+
+func (s IDSpace) UserID(i int) primitive.ObjectID {
+	return s.id(kindUser, s.UserBase+i)
+}
+
+func (s IDSpace) MovieID(i int) primitive.ObjectID {
+	return s.id(kindMovie, s.MovieBase+i)
+}
+
+func (s IDSpace) InteractionID(i int) primitive.ObjectID {
+	return s.id(kindInteraction, s.InteractionBase+i)
+}
+
+func (s IDSpace) faker(kind byte, batch int) *gofakeit.Faker {
+	seed := int64(mix(uint64(s.Seed) ^ uint64(kind)<<56 ^ uint64(batch)))
+	if seed == 0 {
+		seed = 1
+	}
+	return gofakeit.NewUnlocked(seed)
+}
+
+func (s IDSpace) fakers(kind byte) func(batch int) *gofakeit.Faker {
+	return func(batch int) *gofakeit.Faker {
+		return s.faker(kind, batch)
+	}
+}
+
+func (s IDSpace) id(kind byte, i int) primitive.ObjectID {
+	h := fnv.New32a()
+	var seed [8]byte
+	binary.BigEndian.PutUint64(seed[:], uint64(s.Seed))
+	h.Write(seed[:])
+	sum := h.Sum32()
+
+	var id primitive.ObjectID
+	binary.BigEndian.PutUint32(id[0:4], uint32(s.Epoch.Unix()))
+	id[4] = kind
+	id[5] = byte(sum >> 16)
+	id[6] = byte(sum >> 8)
+	id[7] = byte(sum)
+	binary.BigEndian.PutUint32(id[8:12], uint32(i))
+	return id
+}
+
+func (s IDSpace) unit(kind byte, i int) float64 {
+	h := fnv.New64a()
+	var buf [17]byte
+	binary.BigEndian.PutUint64(buf[0:8], uint64(s.Seed))
+	buf[8] = kind
+	binary.BigEndian.PutUint64(buf[9:17], uint64(i))
+	h.Write(buf[:])
+	return float64(mix(h.Sum64())>>11) / (1 << 53)
+}
+
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,264 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const namespaceNotFoundCode = 26
+
+const (
+	IndexPresent  = "present"
+	IndexCreated  = "created"
+	IndexMissing  = "missing"
+	IndexConflict = "conflict"
+	IndexFailed   = "failed"
+	IndexExtra    = "extra"
+)
+
+type IndexSpec struct {
+	Keys   string `json:"keys"`
+	Unique bool   `json:"unique,omitempty"`
+}
+
+type IndexSpecs map[string][]IndexSpec
+
+type IndexResult struct {
+	Collection string
+	Keys       string
+	Unique     bool
+	Name       string
+	Status     string
+	Detail     string
+	BuildTime  time.Duration
+}
+
+func DefaultIndexSpecs() IndexSpecs {
+	return IndexSpecs{
+		"users": {
+			{Keys: "email:1", Unique: true},
+			{Keys: "username:1", Unique: true},
+		},
+		"movies": {
+			{Keys: "title:text,description:text"},
+			{Keys: "genres:1"},
+			{Keys: "releaseYear:1"},
+			{Keys: "rating:-1"},
+		},
+		"interactions": {
+			{Keys: "userId:1"},
+			{Keys: "movieId:1"},
+			{Keys: "type:1"},
+			{Keys: "timestamp:-1"},
+			{Keys: "userId:1,movieId:1,type:1"},
+			{Keys: "userId:1,timestamp:-1"},
+			{Keys: "userId:1,type:1"},
+			{Keys: "movieId:1,type:1"},
+			{Keys: "type:1,timestamp:-1"},
+		},
+	}
+}
+
+func LoadIndexSpecs(path string) (IndexSpecs, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index spec: %w", err)
+	}
+
+	var specs IndexSpecs
+	if err := json.Unmarshal(data, &specs); err != nil {
+		return nil, fmt.Errorf("failed to parse index spec %s: %w", path, err)
+	}
+
+	for collection, indexes := range specs {
+		switch collection {
+		case "users", "movies", "interactions":
+		default:
+			return nil, fmt.Errorf("index spec %s: unknown collection %q (expected users, movies or interactions)", path, collection)
+		}
+		for _, index := range indexes {
+			if _, err := parseIndexKeys(index.Keys); err != nil {
+				return nil, fmt.Errorf("index spec %s: %s: %w", path, collection, err)
+			}
+		}
+	}
+
+	return specs, nil
+}
+
+func parseIndexKeys(keys string) (bson.D, error) {
+	var doc bson.D
+	for _, part := range strings.Split(keys, ",") {
+		field, direction, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if !ok || field == "" {
+			return nil, fmt.Errorf("invalid index key %q (expected field:1, field:-1 or field:text)", part)
+		}
+		switch direction {
+		case "1", "-1":
+			n, _ := strconv.Atoi(direction)
+			doc = append(doc, bson.E{Key: field, Value: n})
+		case "text", "hashed", "2dsphere":
+			doc = append(doc, bson.E{Key: field, Value: direction})
+		default:
+			return nil, fmt.Errorf("invalid direction %q for %s", direction, field)
+		}
+	}
+	return doc, nil
+}
+
+func canonicalKeys(keys bson.D, weights bson.M) string {
+	var parts []string
+	var textFields []string
+	textAt := -1
+
+	for _, key := range keys {
+		internal := key.Key == "_fts" || key.Key == "_ftsx"
+		if internal || key.Value == "text" {
+			if textAt < 0 {
+				textAt = len(parts)
+				parts = append(parts, "")
+			}
+			if !internal {
+				textFields = append(textFields, key.Key)
+			}
+			continue
+		}
+		parts = append(parts, key.Key+":"+formatDirection(key.Value))
+	}
+
+	if textAt >= 0 {
+		for field := range weights {
+			textFields = append(textFields, field)
+		}
+		sort.Strings(textFields)
+		text := make([]string, len(textFields))
+		for i, field := range textFields {
+			text[i] = field + ":text"
+		}
+		parts[textAt] = strings.Join(text, ",")
+	}
+	return strings.Join(parts, ",")
+}
+
+func formatDirection(value interface{}) string {
+	switch v := value.(type) {
+	case int:
+		return strconv.Itoa(v)
+	case int32:
+		return strconv.Itoa(int(v))
+	case int64:
+		return strconv.Itoa(int(v))
+	case float64:
+		return strconv.Itoa(int(v))
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+type existingIndex struct {
+	Name    string `bson:"name"`
+	Key     bson.D `bson:"key"`
+	Unique  bool   `bson:"unique"`
+	Weights bson.M `bson:"weights"`
+}
+
+func listIndexes(ctx context.Context, collection *mongo.Collection) ([]existingIndex, error) {
+	cursor, err := collection.Indexes().List(ctx)
+	if err != nil {
+		var cmdErr mongo.CommandError
+		if errors.As(err, &cmdErr) && cmdErr.Code == namespaceNotFoundCode {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var indexes []existingIndex
+	if err := cursor.All(ctx, &indexes); err != nil {
+		return nil, err
+	}
+	return indexes, nil
+}
+
+func EnsureIndexes(ctx context.Context, db *mongo.Database, collections map[string]string, specs IndexSpecs, create bool) ([]IndexResult, error) {
+	var results []IndexResult
+
+	for _, logical := range []string{"users", "movies", "interactions"} {
+		name := collections[logical]
+		collection := db.Collection(name)
+
+		existing, err := listIndexes(ctx, collection)
+		if err != nil {
+			return results, fmt.Errorf("failed to list indexes on %s: %w", name, err)
+		}
+
+		matched := make(map[string]bool)
+		for _, spec := range specs[logical] {
+			keys, err := parseIndexKeys(spec.Keys)
+			if err != nil {
+				return results, err
+			}
+			canonical := canonicalKeys(keys, nil)
+			result := IndexResult{Collection: name, Keys: canonical, Unique: spec.Unique}
+
+			var found *existingIndex
+			for i := range existing {
+				if canonicalKeys(existing[i].Key, existing[i].Weights) == canonical {
+					found = &existing[i]
+					break
+				}
+			}
+
+			switch {
+			case found != nil:
+				matched[found.Name] = true
+				result.Name = found.Name
+				result.Status = IndexPresent
+				if found.Unique != spec.Unique {
+					result.Status = IndexConflict
+					result.Detail = fmt.Sprintf("unique is %t, spec expects %t", found.Unique, spec.Unique)
+				}
+			case !create:
+				result.Status = IndexMissing
+			default:
+				start := time.Now()
+				model := mongo.IndexModel{Keys: keys, Options: options.Index().SetUnique(spec.Unique)}
+				indexName, err := collection.Indexes().CreateOne(ctx, model)
+				result.BuildTime = time.Since(start)
+				result.Name = indexName
+				result.Status = IndexCreated
+				if err != nil {
+					result.Status = IndexFailed
+					result.Detail = err.Error()
+				}
+			}
+			results = append(results, result)
+		}
+
+		for _, index := range existing {
+			if index.Name == "_id_" || matched[index.Name] {
+				continue
+			}
+			results = append(results, IndexResult{
+				Collection: name,
+				Keys:       canonicalKeys(index.Key, index.Weights),
+				Unique:     index.Unique,
+				Name:       index.Name,
+				Status:     IndexExtra,
+				Detail:     "not in spec",
+			})
+		}
+	}
+
+	return results, nil
+}
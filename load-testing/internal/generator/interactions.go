@@ -3,6 +3,8 @@ package generator
 import (
 	"context"
 	"fmt"
+	"sort"
+	"sync/atomic"
 	"time"
 
 	"github.com/brianvoe/gofakeit/v6"
@@ -13,43 +15,276 @@ import (
 
 var InteractionTypes = []string{"view", "like", "rating", "purchase", "watchlist"}
 
-func GenerateInteractions(ctx context.Context, collection *mongo.Collection, users []models.User, movies []models.Movie, count int) error {
+const (
+	releaseSessionShare = 0.3
+	maxSessionViews     = 6
+	likeChance          = 0.3
+	ratingChance        = 0.25
+	watchlistChance     = 0.2
+	purchaseChance      = 0.35
+)
+
+func GenerateInteractions(ctx context.Context, collection *mongo.Collection, pool *EntityPool, count int, opts Options) error {
 	fmt.Printf("\n🔄 Generating %d interactions...\n", count)
 
-	batch := make([]interface{}, 0, 1000)
+	if count > 0 && (pool.Users() == 0 || pool.Movies() == 0) {
+		return fmt.Errorf("interactions need at least one user and one movie")
+	}
 
-	for i := 0; i < count; i++ {
-		user := users[gofakeit.Number(0, len(users)-1)]
-		movie := movies[gofakeit.Number(0, len(movies)-1)]
-		interactionType := InteractionTypes[gofakeit.Number(0, len(InteractionTypes)-1)]
+	tl := newTimeline(opts.IDs.Epoch, interactionWindow)
+	quota := newQuotas(pool, count, opts.Distribution)
+	offsets := quota.offsets()
+	fakers := opts.IDs.fakers(kindInteraction)
+	var next atomic.Int64
+	return bulkInsert(ctx, collection, opts.Bulk, "interactions", count, func() ([]interface{}, error) {
+		for {
+			b := int(next.Add(1)) - 1
+			lo := b * opts.Bulk.BatchSize
+			if lo >= count {
+				return nil, nil
+			}
+			hi := min(lo+opts.Bulk.BatchSize, count)
+			batch, err := interactionBatch(ctx, fakers(b), lo, hi, count, offsets, quota, tl, opts)
+			if err != nil || len(batch) > 0 {
+				return batch, err
+			}
+		}
+	})
+}
 
-		interaction := models.Interaction{
-			ID:        primitive.NewObjectID(),
-			UserID:    user.ID,
-			MovieID:   movie.ID,
-			Type:      interactionType,
-			Timestamp: gofakeit.DateRange(time.Now().AddDate(0, -3, 0), time.Now()),
+func interactionBatch(ctx context.Context, f *gofakeit.Faker, lo, hi, count int, offsets []int, quota quotas, tl timeline, opts Options) ([]interface{}, error) {
+	users := quota.pool.Users()
+	first := sort.SearchInts(offsets[:users], lo)
+	last := first
+	for last < users && offsets[last] < hi {
+		last++
+	}
+	existing, err := quota.pool.existingActions(ctx, first, last)
+	if err != nil {
+		return nil, err
+	}
+
+	var batch []interface{}
+	for i := first; i < last; i++ {
+		n := min(offsets[i+1], count) - offsets[i]
+		if n <= 0 {
+			continue
+		}
+		userID := quota.pool.UserAt(i)
+		history := userHistory(f, userID, offsets[i+1]-offsets[i], quota.pool, existing[userID], tl, opts.Generation)
+		for k := range history[:n] {
+			history[k].ID = opts.IDs.InteractionID(offsets[i] + k)
+			batch = append(batch, history[k])
+		}
+	}
+	for j := max(lo, offsets[users]); j < hi; j++ {
+		view := extraView(f, quota.activeUser(f), quota.pool, tl, opts.Generation)
+		view.ID = opts.IDs.InteractionID(j)
+		batch = append(batch, view)
+	}
+	return batch, nil
+}
+
+const (
+	regularUser = iota
+	coldStartUser
+	powerUser
+)
+
+type quotas struct {
+	pool   *EntityPool
+	dist   Distribution
+	scales [3]float64
+}
+
+func newQuotas(pool *EntityPool, count int, dist Distribution) quotas {
+	q := quotas{pool: pool, dist: dist}
+
+	var totals [3]float64
+	for i := 0; i < pool.Users(); i++ {
+		totals[q.classOf(i)] += pool.activity(i)
+	}
+
+	shares := [3]float64{regularUser: 1}
+	switch {
+	case totals[powerUser] == 0:
+	case totals[regularUser] == 0:
+		shares = [3]float64{powerUser: 1}
+	default:
+		shares = [3]float64{regularUser: 1 - dist.PowerUserShare, powerUser: dist.PowerUserShare}
+	}
+	for class, total := range totals {
+		if class != coldStartUser && total > 0 {
+			q.scales[class] = float64(count) * shares[class] / total
+		}
+	}
+	return q
+}
+
+func (q quotas) classOf(i int) int {
+	c := q.pool.class(i)
+	switch {
+	case c < q.dist.ColdStartUsers:
+		return coldStartUser
+	case c < q.dist.ColdStartUsers+q.dist.PowerUsers:
+		return powerUser
+	default:
+		return regularUser
+	}
+}
+
+func (q quotas) activeUser(f *gofakeit.Faker) primitive.ObjectID {
+	for attempt := 0; attempt < 100; attempt++ {
+		i := f.Number(0, q.pool.Users()-1)
+		if q.classOf(i) != coldStartUser {
+			return q.pool.UserAt(i)
 		}
+	}
+	return q.pool.User(f)
+}
 
+func (q quotas) of(i int) int {
+	share := q.pool.activity(i) * q.scales[q.classOf(i)]
+	n := int(share)
+	if q.pool.rounding(i) < share-float64(n) {
+		n++
+	}
+	return n
+}
+
+func (q quotas) offsets() []int {
+	offsets := make([]int, q.pool.Users()+1)
+	for i := 0; i < q.pool.Users(); i++ {
+		offsets[i+1] = offsets[i] + q.of(i)
+	}
+	return offsets
+}
+
+func userHistory(f *gofakeit.Faker, userID primitive.ObjectID, quota int, pool *EntityPool, existing map[interactionKey]bool, tl timeline, generation string) []models.Interaction {
+	if quota == 0 {
+		return nil
+	}
+
+	var raw []models.Interaction
+	for {
+		raw = append(raw, newSession(f, userID, pool, tl, generation)...)
+		if len(raw) < quota {
+			continue
+		}
+		if history := consistent(raw, existing); len(history) >= quota {
+			return history[:quota]
+		}
+	}
+}
+
+type interactionKey struct {
+	movieID         primitive.ObjectID
+	interactionType string
+}
+
+func consistent(raw []models.Interaction, existing map[interactionKey]bool) []models.Interaction {
+	sorted := append([]models.Interaction(nil), raw...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+
+	viewed := make(map[primitive.ObjectID]bool)
+	seen := make(map[interactionKey]bool)
+	history := sorted[:0]
+	for _, interaction := range sorted {
+		key := interactionKey{interaction.MovieID, interaction.Type}
+		switch {
+		case interaction.Type == "view":
+			viewed[interaction.MovieID] = true
+		case seen[key] || existing[key]:
+			continue
+		case interaction.Type == "purchase" && !viewed[interaction.MovieID]:
+			continue
+		}
+		seen[key] = true
+		history = append(history, interaction)
+	}
+	return history
+}
+
+func extraView(f *gofakeit.Faker, userID primitive.ObjectID, pool *EntityPool, tl timeline, generation string) models.Interaction {
+	return models.Interaction{
+		UserID:     userID,
+		MovieID:    pool.Movie(f),
+		Type:       "view",
+		Timestamp:  tl.sessionStart(f),
+		Generation: generation,
+	}
+}
+
+func documents(interactions []models.Interaction) []interface{} {
+	docs := make([]interface{}, len(interactions))
+	for i := range interactions {
+		docs[i] = interactions[i]
+	}
+	return docs
+}
+
+func newSession(f *gofakeit.Faker, userID primitive.ObjectID, pool *EntityPool, tl timeline, generation string) []models.Interaction {
+	var start time.Time
+	var movies []primitive.ObjectID
+	if release, ok := pool.NewRelease(f); ok && chance(f, releaseSessionShare) {
+		start = tl.afterRelease(f, tl.releaseTime(release))
+		movies = append(movies, release)
+	} else {
+		start = tl.sessionStart(f)
+	}
+	views := f.Number(1, maxSessionViews)
+	for len(movies) < views {
+		movies = append(movies, pool.Movie(f))
+	}
+
+	var session []models.Interaction
+	add := func(movieID primitive.ObjectID, interactionType string, at time.Time) {
+		interaction := models.Interaction{
+			UserID:     userID,
+			MovieID:    movieID,
+			Type:       interactionType,
+			Timestamp:  at,
+			Generation: generation,
+		}
 		if interactionType == "rating" {
-			rating := gofakeit.Number(1, 10)
+			rating := f.Number(1, 10)
 			interaction.Rating = &rating
 		}
+		session = append(session, interaction)
+	}
 
-		batch = append(batch, interaction)
+	at := start
+	for _, movieID := range movies {
+		add(movieID, "view", at)
+		viewed := at.Add(minutes(f, 1, 10))
 
-		if len(batch) == 1000 || i == count-1 {
-			if _, err := collection.InsertMany(ctx, batch); err != nil {
-				return err
+		if chance(f, likeChance) {
+			add(movieID, "like", viewed.Add(minutes(f, 0, 2)))
+		}
+		if chance(f, ratingChance) {
+			add(movieID, "rating", viewed.Add(minutes(f, 0, 3)))
+		}
+		if chance(f, watchlistChance) {
+			watchlisted := viewed.Add(minutes(f, 0, 2))
+			add(movieID, "watchlist", watchlisted)
+			if chance(f, purchaseChance) {
+				add(movieID, "purchase", watchlisted.Add(minutes(f, 5, 60)))
 			}
-			batch = batch[:0]
 		}
 
-		if (i+1)%1000 == 0 {
-			fmt.Printf("\r   Progress: %d/%d", i+1, count)
-		}
+		at = viewed.Add(minutes(f, 0, 5))
 	}
 
-	fmt.Printf("\n✅ Created %d interactions\n", count)
-	return nil
+	sort.SliceStable(session, func(i, j int) bool {
+		return session[i].Timestamp.Before(session[j].Timestamp)
+	})
+	if last := session[len(session)-1].Timestamp; last.After(tl.end) {
+		shift := last.Sub(tl.end)
+		for i := range session {
+			session[i].Timestamp = session[i].Timestamp.Add(-shift)
+		}
+	}
+	return session
 }
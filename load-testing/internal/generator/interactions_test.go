@@ -0,0 +1,69 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"load-test/internal/models"
+)
+
+func TestConsistent(t *testing.T) {
+	ids := NewIDSpace(1, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	movieA, movieB := ids.MovieID(0), ids.MovieID(1)
+	at := func(minute int) time.Time {
+		return ids.Epoch.Add(time.Duration(minute) * time.Minute)
+	}
+	event := func(minute int, movie primitive.ObjectID, kind string) models.Interaction {
+		return models.Interaction{MovieID: movie, Type: kind, Timestamp: at(minute)}
+	}
+
+	tests := []struct {
+		name     string
+		raw      []models.Interaction
+		existing map[interactionKey]bool
+		want     []models.Interaction
+	}{
+		{
+			name: "sorted by time",
+			raw:  []models.Interaction{event(2, movieA, "like"), event(1, movieA, "view")},
+			want: []models.Interaction{event(1, movieA, "view"), event(2, movieA, "like")},
+		},
+		{
+			name: "repeated views are kept",
+			raw:  []models.Interaction{event(1, movieA, "view"), event(2, movieA, "view")},
+			want: []models.Interaction{event(1, movieA, "view"), event(2, movieA, "view")},
+		},
+		{
+			name: "repeated actions are dropped",
+			raw:  []models.Interaction{event(1, movieA, "like"), event(2, movieA, "like"), event(3, movieB, "like")},
+			want: []models.Interaction{event(1, movieA, "like"), event(3, movieB, "like")},
+		},
+		{
+			name: "purchase needs an earlier view",
+			raw:  []models.Interaction{event(1, movieA, "purchase"), event(2, movieA, "view"), event(3, movieA, "purchase")},
+			want: []models.Interaction{event(2, movieA, "view"), event(3, movieA, "purchase")},
+		},
+		{
+			name: "view of another movie does not count",
+			raw:  []models.Interaction{event(1, movieB, "view"), event(2, movieA, "purchase")},
+			want: []models.Interaction{event(1, movieB, "view")},
+		},
+		{
+			name:     "existing actions are not repeated",
+			raw:      []models.Interaction{event(1, movieA, "view"), event(2, movieA, "rating"), event(3, movieA, "watchlist")},
+			existing: map[interactionKey]bool{{movieA, "rating"}: true},
+			want:     []models.Interaction{event(1, movieA, "view"), event(3, movieA, "watchlist")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := consistent(tt.raw, tt.existing)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("consistent() =\n  %v\nwant\n  %v", got, tt.want)
+			}
+		})
+	}
+}
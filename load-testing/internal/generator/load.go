@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+type fixtureStream struct {
+	mu     sync.Mutex
+	reader *FixtureReader
+	err    error
+}
+
+func (s *fixtureStream) next() ([]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return nil, false
+	}
+	doc, err := s.reader.Next()
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			s.err = err
+		}
+		return nil, false
+	}
+	return []interface{}{doc}, true
+}
+
+func CountFixture(dir, label string) (int, error) {
+	reader, err := OpenFixture(dir, label)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+
+	count := 0
+	for {
+		if _, err := reader.Next(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return count, nil
+			}
+			return count, err
+		}
+		count++
+	}
+}
+
+func LoadFixtures(ctx context.Context, collections Collections, dir string, cfg BulkConfig) (map[string]int, error) {
+	loaded := make(map[string]int)
+	for _, label := range FixtureLabels {
+		count, err := CountFixture(dir, label)
+		if errors.Is(err, os.ErrNotExist) {
+			fmt.Printf("\n⏭️  No %s fixture in %s\n", label, dir)
+			continue
+		}
+		if err != nil {
+			return loaded, err
+		}
+
+		reader, err := OpenFixture(dir, label)
+		if err != nil {
+			return loaded, err
+		}
+		fmt.Printf("\n📥 Loading %d %s from %s...\n", count, label, reader.Path)
+
+		stream := &fixtureStream{reader: reader}
+		err = BulkInsertGroups(ctx, collections.byLabel(label), cfg, label, count, stream.next)
+		reader.Close()
+		if err != nil {
+			return loaded, err
+		}
+		if stream.err != nil {
+			return loaded, stream.err
+		}
+		loaded[label] = count
+	}
+	return loaded, nil
+}
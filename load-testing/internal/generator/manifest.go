@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const ManifestFile = "dataset_manifest.json"
+
+type Manifest struct {
+	Dataset      string            `json:"dataset"`
+	Source       string            `json:"source"`
+	Generation   string            `json:"generation"`
+	Seed         int64             `json:"seed"`
+	Epoch        time.Time         `json:"epoch"`
+	BatchSize    int               `json:"batchSize"`
+	CreatedAt    time.Time         `json:"createdAt"`
+	Append       bool              `json:"append,omitempty"`
+	Database     string            `json:"database,omitempty"`
+	Collections  map[string]string `json:"collections,omitempty"`
+	Counts       map[string]int    `json:"counts"`
+	Offsets      map[string]int    `json:"offsets,omitempty"`
+	Distribution Distribution      `json:"distribution"`
+	PasswordMode string            `json:"passwordMode,omitempty"`
+	BcryptCost   int               `json:"bcryptCost,omitempty"`
+	Credentials  string            `json:"credentials,omitempty"`
+	Export       string            `json:"export,omitempty"`
+}
+
+func WriteManifest(dir string, manifest Manifest) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, ManifestFile)
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write dataset manifest: %w", err)
+	}
+	return path, nil
+}
+
+func ReadManifest(dir string) (Manifest, error) {
+	var manifest Manifest
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return manifest, err
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return manifest, fmt.Errorf("failed to parse %s: %w", filepath.Join(dir, ManifestFile), err)
+	}
+	return manifest, nil
+}
@@ -0,0 +1,503 @@
+package generator
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"go.mongodb.org/mongo-driver/mongo"
+	"load-test/internal/models"
+)
+
+const (
+	MovieLensMovies  = "movies.csv"
+	MovieLensRatings = "ratings.csv"
+	MovieLensTags    = "tags.csv"
+
+	maxMovieTags     = 5
+	maxFavoriteGenre = 3
+	maxDislikedGenre = 2
+	favoriteRating   = 8
+	dislikedRating   = 4
+	likeRating       = 9
+)
+
+var movieLensGenres = map[string]string{
+	"Action":      "Action",
+	"Adventure":   "Adventure",
+	"Animation":   "Animation",
+	"Children":    "Animation",
+	"Comedy":      "Comedy",
+	"Crime":       "Crime",
+	"Documentary": "Documentary",
+	"Drama":       "Drama",
+	"Fantasy":     "Fantasy",
+	"Film-Noir":   "Crime",
+	"Horror":      "Horror",
+	"Musical":     "Romance",
+	"Mystery":     "Mystery",
+	"Romance":     "Romance",
+	"Sci-Fi":      "Sci-Fi",
+	"Thriller":    "Thriller",
+	"War":         "Drama",
+	"Western":     "Adventure",
+}
+
+var titleYear = regexp.MustCompile(`\s*\((\d{4})\)\s*$`)
+
+type MovieLensStats struct {
+	Movies       int
+	Users        int
+	Ratings      int
+	Tags         int
+	Skipped      int
+	Interactions int
+}
+
+type movieLensMovie struct {
+	title     string
+	year      int
+	genres    []string
+	ratingSum int
+	ratings   int
+	tags      map[string]int
+}
+
+type movieLensUser struct {
+	liked    []int
+	disliked []int
+}
+
+type movieLensRating struct {
+	userID    int
+	movieID   int
+	rating    int
+	timestamp time.Time
+}
+
+func ImportMovieLens(ctx context.Context, collections Collections, dir string, opts Options) (MovieLensStats, error) {
+	fmt.Printf("\n📥 Importing MovieLens data from %s...\n", dir)
+
+	var stats MovieLensStats
+	movies, err := readMovieLensMovies(filepath.Join(dir, MovieLensMovies))
+	if err != nil {
+		return stats, err
+	}
+	stats.Movies = len(movies)
+
+	stats.Tags, err = readMovieLensTags(filepath.Join(dir, MovieLensTags), movies)
+	if err != nil {
+		return stats, err
+	}
+
+	users, err := scanMovieLensRatings(filepath.Join(dir, MovieLensRatings), movies, &stats)
+	if err != nil {
+		return stats, err
+	}
+	stats.Users = len(users)
+
+	fmt.Printf("   %d movies, %d users, %d ratings, %d tags", stats.Movies, stats.Users, stats.Ratings, stats.Tags)
+	if stats.Skipped > 0 {
+		fmt.Printf(" (%d ratings skipped for unknown movies)", stats.Skipped)
+	}
+	fmt.Println()
+
+	if err := importMovieLensUsers(ctx, collections.Users, users, opts); err != nil {
+		return stats, err
+	}
+	if err := importMovieLensMovies(ctx, collections.Movies, movies, opts); err != nil {
+		return stats, err
+	}
+	if err := importMovieLensRatings(ctx, collections.Interactions, filepath.Join(dir, MovieLensRatings), movies, stats.Interactions, opts); err != nil {
+		return stats, err
+	}
+	return stats, nil
+}
+
+func openMovieLensCSV(path string, required ...string) (*os.File, *csv.Reader, map[string]int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	reader := csv.NewReader(file)
+	header, err := reader.Read()
+	if err != nil {
+		file.Close()
+		return nil, nil, nil, fmt.Errorf("failed to read header of %s: %w", path, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))] = i
+	}
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			file.Close()
+			return nil, nil, nil, fmt.Errorf("%s: missing column %q (expected %s)", path, name, strings.Join(required, ","))
+		}
+	}
+	reader.FieldsPerRecord = len(header)
+	return file, reader, columns, nil
+}
+
+func readMovieLensMovies(path string) (map[int]*movieLensMovie, error) {
+	file, reader, columns, err := openMovieLensCSV(path, "movieId", "title", "genres")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open movies: %w", err)
+	}
+	defer file.Close()
+
+	movies := make(map[int]*movieLensMovie)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return movies, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		id, err := strconv.Atoi(record[columns["movieId"]])
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid movieId %q", path, record[columns["movieId"]])
+		}
+		title, year := splitMovieLensTitle(record[columns["title"]])
+		movies[id] = &movieLensMovie{
+			title:  title,
+			year:   year,
+			genres: mapMovieLensGenres(record[columns["genres"]]),
+		}
+	}
+}
+
+func splitMovieLensTitle(raw string) (string, int) {
+	title := strings.TrimSpace(raw)
+	year := 0
+	if match := titleYear.FindStringSubmatch(title); match != nil {
+		year, _ = strconv.Atoi(match[1])
+		title = strings.TrimSpace(title[:len(title)-len(match[0])])
+	}
+	for _, article := range []string{"The", "A", "An"} {
+		if base, ok := strings.CutSuffix(title, ", "+article); ok {
+			title = article + " " + base
+			break
+		}
+	}
+	return title, year
+}
+
+func mapMovieLensGenres(raw string) []string {
+	var genres []string
+	for _, genre := range strings.Split(raw, "|") {
+		if mapped, ok := movieLensGenres[genre]; ok && !contains(genres, mapped) {
+			genres = append(genres, mapped)
+		}
+	}
+	return genres
+}
+
+func readMovieLensTags(path string, movies map[int]*movieLensMovie) (int, error) {
+	file, reader, columns, err := openMovieLensCSV(path, "movieId", "tag")
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to open tags: %w", err)
+	}
+	defer file.Close()
+
+	count := 0
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return count, nil
+		}
+		if err != nil {
+			return count, fmt.Errorf("%s: %w", path, err)
+		}
+
+		id, err := strconv.Atoi(record[columns["movieId"]])
+		movie := movies[id]
+		tag := strings.ToLower(strings.TrimSpace(record[columns["tag"]]))
+		if err != nil || movie == nil || tag == "" {
+			continue
+		}
+		if movie.tags == nil {
+			movie.tags = make(map[string]int)
+		}
+		movie.tags[tag]++
+		count++
+	}
+}
+
+func parseMovieLensRating(record []string, columns map[string]int) (movieLensRating, error) {
+	var r movieLensRating
+	var err error
+	if r.userID, err = strconv.Atoi(record[columns["userId"]]); err != nil {
+		return r, fmt.Errorf("invalid userId %q", record[columns["userId"]])
+	}
+	if r.movieID, err = strconv.Atoi(record[columns["movieId"]]); err != nil {
+		return r, fmt.Errorf("invalid movieId %q", record[columns["movieId"]])
+	}
+
+	stars, err := strconv.ParseFloat(record[columns["rating"]], 64)
+	if err != nil || stars < 0.5 || stars > 5 {
+		return r, fmt.Errorf("invalid rating %q (expected 0.5-5)", record[columns["rating"]])
+	}
+	r.rating = int(math.Round(stars * 2))
+
+	seconds, err := strconv.ParseInt(record[columns["timestamp"]], 10, 64)
+	if err != nil {
+		return r, fmt.Errorf("invalid timestamp %q", record[columns["timestamp"]])
+	}
+	r.timestamp = time.Unix(seconds, 0).UTC()
+	return r, nil
+}
+
+func (r movieLensRating) interactionCount() int {
+	if r.rating >= likeRating {
+		return 3
+	}
+	return 2
+}
+
+func (r movieLensRating) interactions(f *gofakeit.Faker, opts Options) []models.Interaction {
+	userID := opts.IDs.UserID(r.userID)
+	movieID := opts.IDs.MovieID(r.movieID)
+	rating := r.rating
+
+	interactions := []models.Interaction{
+		{UserID: userID, MovieID: movieID, Type: "view", Timestamp: r.timestamp.Add(-minutes(f, 90, 200)), Generation: opts.Generation},
+		{UserID: userID, MovieID: movieID, Type: "rating", Rating: &rating, Timestamp: r.timestamp, Generation: opts.Generation},
+	}
+	if r.rating >= likeRating {
+		interactions = append(interactions, models.Interaction{
+			UserID: userID, MovieID: movieID, Type: "like", Timestamp: r.timestamp.Add(minutes(f, 0, 2)), Generation: opts.Generation,
+		})
+	}
+	return interactions
+}
+
+func scanMovieLensRatings(path string, movies map[int]*movieLensMovie, stats *MovieLensStats) (map[int]*movieLensUser, error) {
+	file, reader, columns, err := openMovieLensCSV(path, "userId", "movieId", "rating", "timestamp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ratings: %w", err)
+	}
+	defer file.Close()
+
+	genreIndex := make(map[string]int, len(Genres))
+	for i, genre := range Genres {
+		genreIndex[genre] = i
+	}
+
+	users := make(map[int]*movieLensUser)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return users, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		r, err := parseMovieLensRating(record, columns)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+
+		movie := movies[r.movieID]
+		if movie == nil {
+			stats.Skipped++
+			continue
+		}
+		movie.ratingSum += r.rating
+		movie.ratings++
+		stats.Ratings++
+		stats.Interactions += r.interactionCount()
+
+		user := users[r.userID]
+		if user == nil {
+			user = &movieLensUser{liked: make([]int, len(Genres)), disliked: make([]int, len(Genres))}
+			users[r.userID] = user
+		}
+		switch {
+		case r.rating >= favoriteRating:
+			for _, genre := range movie.genres {
+				user.liked[genreIndex[genre]]++
+			}
+		case r.rating <= dislikedRating:
+			for _, genre := range movie.genres {
+				user.disliked[genreIndex[genre]]++
+			}
+		}
+	}
+}
+
+func (u *movieLensUser) favoriteGenres() []string {
+	return topGenres(u.liked, maxFavoriteGenre, nil)
+}
+
+func (u *movieLensUser) dislikedGenres() []string {
+	return topGenres(u.disliked, maxDislikedGenre, u.favoriteGenres())
+}
+
+func topGenres(counts []int, n int, exclude []string) []string {
+	order := make([]int, len(Genres))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return counts[order[a]] > counts[order[b]]
+	})
+
+	genres := make([]string, 0, n)
+	for _, i := range order {
+		if len(genres) == n || counts[i] == 0 {
+			break
+		}
+		if !contains(exclude, Genres[i]) {
+			genres = append(genres, Genres[i])
+		}
+	}
+	return genres
+}
+
+func (m *movieLensMovie) topTags() []string {
+	tags := make([]string, 0, len(m.tags))
+	for tag := range m.tags {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(a, b int) bool {
+		if m.tags[tags[a]] != m.tags[tags[b]] {
+			return m.tags[tags[a]] > m.tags[tags[b]]
+		}
+		return tags[a] < tags[b]
+	})
+	return tags[:min(len(tags), maxMovieTags)]
+}
+
+func sortedKeys[T any](m map[int]T) []int {
+	keys := make([]int, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+func importMovieLensUsers(ctx context.Context, collection *mongo.Collection, users map[int]*movieLensUser, opts Options) error {
+	fmt.Printf("\n👥 Importing %d users...\n", len(users))
+
+	password, err := opts.Passwords.source()
+	if err != nil {
+		return err
+	}
+
+	ids := sortedKeys(users)
+	return BulkInsert(ctx, collection, opts.Bulk, "users", len(ids), opts.IDs.fakers(kindUser), func(f *gofakeit.Faker, i int) (interface{}, error) {
+		id := ids[i]
+		user := newUser(f, opts.IDs.UserID(id), id, users[id].favoriteGenres(), users[id].dislikedGenres(), opts)
+		var err error
+		user.PlainPassword, user.Password, err = password(f)
+		return user, err
+	})
+}
+
+func importMovieLensMovies(ctx context.Context, collection *mongo.Collection, movies map[int]*movieLensMovie, opts Options) error {
+	fmt.Printf("\n🎬 Importing %d movies...\n", len(movies))
+
+	ids := sortedKeys(movies)
+	return BulkInsert(ctx, collection, opts.Bulk, "movies", len(ids), opts.IDs.fakers(kindMovie), func(f *gofakeit.Faker, i int) (interface{}, error) {
+		source := movies[ids[i]]
+
+		movie := NewMovie(f, opts.IDs.Epoch)
+		movie.ID = opts.IDs.MovieID(ids[i])
+		movie.Title = source.title
+		movie.Generation = opts.Generation
+		if len(source.genres) > 0 {
+			movie.Genres = source.genres
+		}
+		if source.year > 0 {
+			movie.ReleaseYear = source.year
+		}
+		if source.ratings > 0 {
+			movie.Rating = math.Round(float64(source.ratingSum)/float64(source.ratings)*10) / 10
+		}
+		if tags := source.topTags(); len(tags) > 0 {
+			movie.Description = fmt.Sprintf("%s. Tagged: %s.", source.title, strings.Join(tags, ", "))
+		}
+		return movie, nil
+	})
+}
+
+type movieLensRatingStream struct {
+	mu       sync.Mutex
+	reader   *csv.Reader
+	columns  map[string]int
+	movies   map[int]*movieLensMovie
+	opts     Options
+	rng      *gofakeit.Faker
+	imported int
+	err      error
+}
+
+func (s *movieLensRatingStream) next() ([]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for s.err == nil {
+		record, err := s.reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil, false
+		}
+		if err != nil {
+			s.err = err
+			break
+		}
+		r, err := parseMovieLensRating(record, s.columns)
+		if err != nil {
+			s.err = err
+			break
+		}
+		if s.movies[r.movieID] != nil {
+			interactions := r.interactions(s.rng, s.opts)
+			for i := range interactions {
+				interactions[i].ID = s.opts.IDs.InteractionID(s.imported)
+				s.imported++
+			}
+			return documents(interactions), true
+		}
+	}
+	return nil, false
+}
+
+func importMovieLensRatings(ctx context.Context, collection *mongo.Collection, path string, movies map[int]*movieLensMovie, count int, opts Options) error {
+	fmt.Printf("\n🔄 Importing ratings as %d interactions...\n", count)
+
+	file, reader, columns, err := openMovieLensCSV(path, "userId", "movieId", "rating", "timestamp")
+	if err != nil {
+		return fmt.Errorf("failed to open ratings: %w", err)
+	}
+	defer file.Close()
+
+	stream := &movieLensRatingStream{reader: reader, columns: columns, movies: movies, opts: opts, rng: opts.IDs.faker(kindInteraction, 0)}
+	if err := BulkInsertGroups(ctx, collection, opts.Bulk, "interactions", count, stream.next); err != nil {
+		return err
+	}
+	if stream.err != nil {
+		return fmt.Errorf("%s: %w", path, stream.err)
+	}
+	return nil
+}
@@ -25,70 +25,60 @@ var Actors = []string{
 	"Morgan Freeman", "Jennifer Lawrence", "Matt Damon", "Natalie Portman",
 }
 
-func GenerateMovies(ctx context.Context, collection *mongo.Collection, count int) ([]models.Movie, error) {
+func GenerateMovies(ctx context.Context, collection *mongo.Collection, count int, opts Options) error {
 	fmt.Printf("\n🎬 Generating %d movies...\n", count)
 
-	movies := make([]models.Movie, count)
-	batch := make([]interface{}, 0, 1000)
-
-	for i := 0; i < count; i++ {
-		genreCount := gofakeit.Number(1, 3)
-		genres := make([]string, genreCount)
-		for j := 0; j < genreCount; j++ {
-			genres[j] = Genres[gofakeit.Number(0, len(Genres)-1)]
-		}
-
-		castCount := gofakeit.Number(3, 8)
-		cast := make([]string, castCount)
-		for j := 0; j < castCount; j++ {
-			cast[j] = Actors[gofakeit.Number(0, len(Actors)-1)]
-		}
-
-		movie := models.Movie{
-			ID:          primitive.NewObjectID(),
-			Title:       generateMovieTitle(),
-			Description: gofakeit.Paragraph(2, 3, 10, " "),
-			Genres:      genres,
-			Director:    Directors[gofakeit.Number(0, len(Directors)-1)],
-			Cast:        cast,
-			ReleaseYear: gofakeit.Number(1990, 2024),
-			Duration:    gofakeit.Number(80, 180),
-			Rating:      float64(gofakeit.Number(50, 100)) / 10.0,
-			PosterURL:   gofakeit.ImageURL(300, 450),
-			TrailerURL:  fmt.Sprintf("https://youtube.com/watch?v=%s", gofakeit.LetterN(11)),
-			Price:       float64(gofakeit.Number(499, 1999)) / 100.0,
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
-		}
-
-		movies[i] = movie
-		batch = append(batch, movie)
-
-		if len(batch) == 1000 || i == count-1 {
-			if _, err := collection.InsertMany(ctx, batch); err != nil {
-				return nil, err
-			}
-			batch = batch[:0]
+	return BulkInsert(ctx, collection, opts.Bulk, "movies", count, opts.IDs.fakers(kindMovie), func(f *gofakeit.Faker, i int) (interface{}, error) {
+		movie := NewMovie(f, opts.IDs.Epoch)
+		movie.ID = opts.IDs.MovieID(i)
+		movie.Generation = opts.Generation
+		if opts.NewReleases {
+			movie.ReleaseYear = opts.IDs.Epoch.Year()
 		}
+		return movie, nil
+	})
+}
 
-		if (i+1)%100 == 0 {
-			fmt.Printf("\r   Progress: %d/%d", i+1, count)
-		}
+func generateMovieTitle(f *gofakeit.Faker) string {
+	templates := []func() string{
+		func() string { return fmt.Sprintf("The %s %s", f.AdjectiveDescriptive(), f.Noun()) },
+		func() string { return fmt.Sprintf("%s %s", f.AdjectiveDescriptive(), f.Noun()) },
+		func() string { return fmt.Sprintf("%s's %s", f.LastName(), f.Noun()) },
+		func() string { return fmt.Sprintf("The %s of %s", f.Noun(), f.City()) },
+		func() string { return fmt.Sprintf("%d %ss", f.Number(1, 100), f.Noun()) },
 	}
 
-	fmt.Printf("\n✅ Created %d movies\n", count)
-	return movies, nil
+	template := templates[f.Number(0, len(templates)-1)]
+	return template()
 }
 
-func generateMovieTitle() string {
-	templates := []func() string{
-		func() string { return fmt.Sprintf("The %s %s", gofakeit.AdjectiveDescriptive(), gofakeit.Noun()) },
-		func() string { return fmt.Sprintf("%s %s", gofakeit.AdjectiveDescriptive(), gofakeit.Noun()) },
-		func() string { return fmt.Sprintf("%s's %s", gofakeit.LastName(), gofakeit.Noun()) },
-		func() string { return fmt.Sprintf("The %s of %s", gofakeit.Noun(), gofakeit.City()) },
-		func() string { return fmt.Sprintf("%d %ss", gofakeit.Number(1, 100), gofakeit.Noun()) },
+func NewMovie(f *gofakeit.Faker, createdAt time.Time) models.Movie {
+	genreCount := f.Number(1, 3)
+	genres := make([]string, genreCount)
+	for j := 0; j < genreCount; j++ {
+		genres[j] = Genres[f.Number(0, len(Genres)-1)]
 	}
 
-	template := templates[gofakeit.Number(0, len(templates)-1)]
-	return template()
+	castCount := f.Number(3, 8)
+	cast := make([]string, castCount)
+	for j := 0; j < castCount; j++ {
+		cast[j] = Actors[f.Number(0, len(Actors)-1)]
+	}
+
+	return models.Movie{
+		ID:          primitive.NewObjectID(),
+		Title:       generateMovieTitle(f),
+		Description: f.Paragraph(2, 3, 10, " "),
+		Genres:      genres,
+		Director:    Directors[f.Number(0, len(Directors)-1)],
+		Cast:        cast,
+		ReleaseYear: f.Number(1990, 2024),
+		Duration:    f.Number(80, 180),
+		Rating:      float64(f.Number(50, 100)) / 10.0,
+		PosterURL:   f.ImageURL(300, 450),
+		TrailerURL:  fmt.Sprintf("https://youtube.com/watch?v=%s", f.LetterN(11)),
+		Price:       float64(f.Number(499, 1999)) / 100.0,
+		CreatedAt:   createdAt,
+		UpdatedAt:   createdAt,
+	}
 }
@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"golang.org/x/crypto/bcrypt"
+	"load-test/internal/config"
+)
+
+const randomPasswordLength = 16
+
+type PasswordConfig struct {
+	Mode       string
+	Shared     string
+	BcryptCost int
+}
+
+func (c PasswordConfig) source() (func(f *gofakeit.Faker) (string, string, error), error) {
+	cost := c.BcryptCost
+	if cost == 0 {
+		cost = bcrypt.DefaultCost
+	}
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		return nil, fmt.Errorf("invalid bcrypt cost %d (expected %d-%d)", cost, bcrypt.MinCost, bcrypt.MaxCost)
+	}
+
+	switch c.Mode {
+	case config.PasswordShared, "":
+		plain := c.Shared
+		if plain == "" {
+			plain = config.DefaultPassword
+		}
+		hash, err := bcrypt.GenerateFromPassword([]byte(plain), cost)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash shared password: %w", err)
+		}
+		return func(*gofakeit.Faker) (string, string, error) { return plain, string(hash), nil }, nil
+	case config.PasswordRandom:
+		return func(f *gofakeit.Faker) (string, string, error) {
+			plain := f.Password(true, true, true, false, false, randomPasswordLength)
+			hash, err := bcrypt.GenerateFromPassword([]byte(plain), cost)
+			if err != nil {
+				return "", "", fmt.Errorf("failed to hash random password: %w", err)
+			}
+			return plain, string(hash), nil
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown password mode %q (expected shared|random)", c.Mode)
+	}
+}
@@ -0,0 +1,195 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const newReleaseFraction = 0.1
+
+type EntityPool struct {
+	ids       IDSpace
+	users     []primitive.ObjectID
+	movies    []primitive.ObjectID
+	actions   *mongo.Collection
+	existing  int64
+	newUsers  int
+	newMovies int
+	recent    int
+	skew      float64
+}
+
+func NewEntityPool(ids IDSpace, newUsers, newMovies int) *EntityPool {
+	recent := int(float64(newMovies) * newReleaseFraction)
+	return &EntityPool{ids: ids, newUsers: newUsers, newMovies: newMovies, recent: recent, skew: 1}
+}
+
+func LoadEntityPool(ctx context.Context, collections Collections, ids IDSpace, newUsers, newMovies int) (*EntityPool, error) {
+	pool := NewEntityPool(ids, newUsers, newMovies)
+
+	var err error
+	if pool.users, err = loadIDs(ctx, collections.Users); err != nil {
+		return nil, fmt.Errorf("failed to load existing users: %w", err)
+	}
+	if pool.movies, err = loadIDs(ctx, collections.Movies); err != nil {
+		return nil, fmt.Errorf("failed to load existing movies: %w", err)
+	}
+	if pool.existing, err = collections.Interactions.CountDocuments(ctx, bson.M{"type": bson.M{"$ne": "view"}}); err != nil {
+		return nil, fmt.Errorf("failed to count existing interactions: %w", err)
+	}
+	pool.actions = collections.Interactions
+	interactions, err := collections.Interactions.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count existing interactions: %w", err)
+	}
+
+	// The same seed and epoch would otherwise regenerate the IDs already in
+	// the collections, so new documents are numbered after the existing ones.
+	pool.ids = ids.After(len(pool.users), len(pool.movies), int(interactions))
+
+	pool.recent = newMovies
+	if pool.recent == 0 {
+		pool.recent = int(float64(len(pool.movies)) * newReleaseFraction)
+	}
+	return pool, nil
+}
+
+func loadIDs(ctx context.Context, collection *mongo.Collection) ([]primitive.ObjectID, error) {
+	opts := options.Find().
+		SetProjection(bson.M{"_id": 1}).
+		SetSort(bson.M{"_id": 1}).
+		SetBatchSize(10000)
+
+	cursor, err := collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var ids []primitive.ObjectID
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		ids = append(ids, doc.ID)
+	}
+	return ids, cursor.Err()
+}
+
+func (p *EntityPool) existingActions(ctx context.Context, first, last int) (map[primitive.ObjectID]map[interactionKey]bool, error) {
+	last = min(last, len(p.users))
+	if p.actions == nil || first >= last {
+		return nil, nil
+	}
+
+	filter := bson.M{"userId": bson.M{"$in": p.users[first:last]}, "type": bson.M{"$ne": "view"}}
+	opts := options.Find().
+		SetProjection(bson.M{"_id": 0, "userId": 1, "movieId": 1, "type": 1}).
+		SetBatchSize(10000)
+
+	cursor, err := p.actions.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load existing interactions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	actions := make(map[primitive.ObjectID]map[interactionKey]bool)
+	for cursor.Next(ctx) {
+		var doc struct {
+			UserID  primitive.ObjectID `bson:"userId"`
+			MovieID primitive.ObjectID `bson:"movieId"`
+			Type    string             `bson:"type"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		if actions[doc.UserID] == nil {
+			actions[doc.UserID] = make(map[interactionKey]bool)
+		}
+		actions[doc.UserID][interactionKey{doc.MovieID, doc.Type}] = true
+	}
+	return actions, cursor.Err()
+}
+
+func (p *EntityPool) ExistingUsers() int {
+	return len(p.users)
+}
+
+func (p *EntityPool) ExistingMovies() int {
+	return len(p.movies)
+}
+
+func (p *EntityPool) Users() int {
+	return len(p.users) + p.newUsers
+}
+
+func (p *EntityPool) Movies() int {
+	return len(p.movies) + p.newMovies
+}
+
+func (p *EntityPool) User(f *gofakeit.Faker) primitive.ObjectID {
+	return p.UserAt(f.Number(0, p.Users()-1))
+}
+
+func (p *EntityPool) UserAt(i int) primitive.ObjectID {
+	if i < len(p.users) {
+		return p.users[i]
+	}
+	return p.ids.UserID(i - len(p.users))
+}
+
+func (p *EntityPool) IDs() IDSpace {
+	return p.ids
+}
+
+func (p *EntityPool) ExistingActions() int64 {
+	return p.existing
+}
+
+func (p *EntityPool) SetPopularitySkew(skew float64) {
+	p.skew = max(skew, 1)
+}
+
+func (p *EntityPool) activity(i int) float64 {
+	return -math.Log(1 - p.ids.unit(kindUnit, i))
+}
+
+func (p *EntityPool) rounding(i int) float64 {
+	return p.ids.unit(kindQuota, i)
+}
+
+func (p *EntityPool) class(i int) float64 {
+	return p.ids.unit(kindClass, i)
+}
+
+func (p *EntityPool) Movie(f *gofakeit.Faker) primitive.ObjectID {
+	if p.skew == 1 {
+		return p.movie(f.Number(0, p.Movies()-1))
+	}
+	i := int(float64(p.Movies()) * math.Pow(unit(f), p.skew))
+	return p.movie(min(i, p.Movies()-1))
+}
+
+func (p *EntityPool) NewRelease(f *gofakeit.Faker) (primitive.ObjectID, bool) {
+	if p.recent == 0 {
+		return primitive.NilObjectID, false
+	}
+	return p.movie(p.Movies() - p.recent + f.Number(0, p.recent-1)), true
+}
+
+func (p *EntityPool) movie(i int) primitive.ObjectID {
+	if i < len(p.movies) {
+		return p.movies[i]
+	}
+	return p.ids.MovieID(i - len(p.movies))
+}
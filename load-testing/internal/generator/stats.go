@@ -0,0 +1,301 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"load-test/internal/models"
+)
+
+const topShareFraction = 0.01
+
+func AnalyzeDataset(ctx context.Context, db *mongo.Database, collections map[string]string, generation string) (models.DatasetStats, error) {
+	stats := models.DatasetStats{
+		Database:   db.Name(),
+		Generation: generation,
+		AnalyzedAt: time.Now(),
+	}
+	filter := bson.M{}
+	if generation != "" {
+		filter = GenerationFilter(generation)
+	}
+	users := db.Collection(collections["users"])
+	movies := db.Collection(collections["movies"])
+	interactions := db.Collection(collections["interactions"])
+
+	userIDs, err := filteredIDs(ctx, users, filter)
+	if err != nil {
+		return stats, fmt.Errorf("failed to read users: %w", err)
+	}
+	movieGenres, err := loadMovieGenres(ctx, movies, filter)
+	if err != nil {
+		return stats, fmt.Errorf("failed to read movies: %w", err)
+	}
+	stats.Users = int64(len(userIDs))
+	stats.Movies = int64(len(movieGenres))
+
+	perUser, err := countBy(ctx, interactions, filter, "$userId")
+	if err != nil {
+		return stats, fmt.Errorf("failed to count interactions per user: %w", err)
+	}
+	var userCounts []int64
+	for id, count := range perUser {
+		stats.Interactions += count
+		if !containsID(userIDs, id) {
+			stats.UnknownUsers++
+			continue
+		}
+		userCounts = append(userCounts, count)
+	}
+	stats.ColdStartUsers = stats.Users - int64(len(userCounts))
+	stats.PerUser = distribution(userCounts, stats.ColdStartUsers)
+
+	perMovie, err := countBy(ctx, interactions, filter, "$movieId")
+	if err != nil {
+		return stats, fmt.Errorf("failed to count interactions per movie: %w", err)
+	}
+	genres := make(map[string]*models.GenreCount)
+	genre := func(name string) *models.GenreCount {
+		if genres[name] == nil {
+			genres[name] = &models.GenreCount{Genre: name}
+		}
+		return genres[name]
+	}
+	for _, names := range movieGenres {
+		for _, name := range names {
+			genre(name).Movies++
+		}
+	}
+	var movieCounts []int64
+	for id, count := range perMovie {
+		oid, _ := id.(primitive.ObjectID)
+		names, known := movieGenres[oid]
+		if !known {
+			stats.UnknownMovies++
+			continue
+		}
+		movieCounts = append(movieCounts, count)
+		for _, name := range names {
+			genre(name).Interactions += count
+		}
+	}
+	stats.ColdStartMovies = stats.Movies - int64(len(movieCounts))
+	stats.PerMovie = distribution(movieCounts, stats.ColdStartMovies)
+	for _, g := range genres {
+		stats.Genres = append(stats.Genres, *g)
+	}
+	sort.Slice(stats.Genres, func(i, j int) bool {
+		if stats.Genres[i].Interactions != stats.Genres[j].Interactions {
+			return stats.Genres[i].Interactions > stats.Genres[j].Interactions
+		}
+		return stats.Genres[i].Genre < stats.Genres[j].Genre
+	})
+
+	if stats.UserMoviePairs, err = countPairs(ctx, interactions, filter); err != nil {
+		return stats, fmt.Errorf("failed to count user-movie pairs: %w", err)
+	}
+	if cells := float64(stats.Users) * float64(stats.Movies); cells > 0 {
+		stats.Density = float64(stats.UserMoviePairs) / cells
+		stats.Sparsity = 1 - stats.Density
+	}
+
+	typeCounts, err := countBy(ctx, interactions, filter, "$type")
+	if err != nil {
+		return stats, fmt.Errorf("failed to count interaction types: %w", err)
+	}
+	for _, name := range InteractionTypes {
+		stats.Types = append(stats.Types, models.NamedCount{Name: name, Count: typeCounts[name]})
+		delete(typeCounts, name)
+	}
+	var others []models.NamedCount
+	for name, count := range typeCounts {
+		others = append(others, models.NamedCount{Name: fmt.Sprint(name), Count: count})
+	}
+	sort.Slice(others, func(i, j int) bool { return others[i].Name < others[j].Name })
+	stats.Types = append(stats.Types, others...)
+
+	ratingFilter := bson.M{"type": "rating"}
+	for key, value := range filter {
+		ratingFilter[key] = value
+	}
+	ratingCounts, err := countBy(ctx, interactions, ratingFilter, "$rating")
+	if err != nil {
+		return stats, fmt.Errorf("failed to count ratings: %w", err)
+	}
+	for rating := 1; rating <= 10; rating++ {
+		count := ratingCounts[int32(rating)] + ratingCounts[int64(rating)] + ratingCounts[float64(rating)]
+		stats.Ratings = append(stats.Ratings, models.NamedCount{Name: strconv.Itoa(rating), Count: count})
+	}
+
+	return stats, nil
+}
+
+func filteredIDs(ctx context.Context, collection *mongo.Collection, filter bson.M) ([]primitive.ObjectID, error) {
+	opts := options.Find().
+		SetProjection(bson.M{"_id": 1}).
+		SetSort(bson.M{"_id": 1}).
+		SetBatchSize(10000)
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var ids []primitive.ObjectID
+	for cursor.Next(ctx) {
+		id, ok := cursor.Current.Lookup("_id").ObjectIDOK()
+		if !ok {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, cursor.Err()
+}
+
+func containsID(sorted []primitive.ObjectID, id interface{}) bool {
+	oid, ok := id.(primitive.ObjectID)
+	if !ok {
+		return false
+	}
+	i := sort.Search(len(sorted), func(i int) bool { return bytes.Compare(sorted[i][:], oid[:]) >= 0 })
+	return i < len(sorted) && sorted[i] == oid
+}
+
+func loadMovieGenres(ctx context.Context, collection *mongo.Collection, filter bson.M) (map[primitive.ObjectID][]string, error) {
+	opts := options.Find().
+		SetProjection(bson.M{"_id": 1, "genres": 1}).
+		SetBatchSize(10000)
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	genres := make(map[primitive.ObjectID][]string)
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID     primitive.ObjectID `bson:"_id"`
+			Genres []string           `bson:"genres"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		genres[doc.ID] = doc.Genres
+	}
+	return genres, cursor.Err()
+}
+
+func countBy(ctx context.Context, collection *mongo.Collection, filter bson.M, key string) (map[interface{}]int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{"_id": key, "count": bson.M{"$sum": 1}}}},
+	}
+	cursor, err := collection.Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	counts := make(map[interface{}]int64)
+	for cursor.Next(ctx) {
+		var row struct {
+			ID    interface{} `bson:"_id"`
+			Count int64       `bson:"count"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			return nil, err
+		}
+		counts[row.ID] = row.Count
+	}
+	return counts, cursor.Err()
+}
+
+func countPairs(ctx context.Context, collection *mongo.Collection, filter bson.M) (int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{"_id": bson.M{"u": "$userId", "m": "$movieId"}}}},
+		{{Key: "$count", Value: "pairs"}},
+	}
+	cursor, err := collection.Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		Pairs int64 `bson:"pairs"`
+	}
+	if err := cursor.All(ctx, &rows); err != nil || len(rows) == 0 {
+		return 0, err
+	}
+	return rows[0].Pairs, nil
+}
+
+func distribution(counts []int64, zeros int64) models.CountDistribution {
+	all := make([]int64, zeros, int(zeros)+len(counts))
+	all = append(all, counts...)
+	sort.Slice(all, func(i, j int) bool { return all[i] < all[j] })
+
+	var dist models.CountDistribution
+	if len(all) == 0 {
+		return dist
+	}
+
+	var total, weighted float64
+	for i, count := range all {
+		total += float64(count)
+		weighted += float64(i+1) * float64(count)
+	}
+	n := float64(len(all))
+	at := func(p float64) int64 {
+		return all[min(int(p*n), len(all)-1)]
+	}
+
+	dist.Mean = total / n
+	dist.Median = at(0.5)
+	dist.P90 = at(0.9)
+	dist.P99 = at(0.99)
+	dist.Max = all[len(all)-1]
+	if total > 0 {
+		dist.Gini = 2*weighted/(n*total) - (n+1)/n
+
+		top := max(1, int(math.Ceil(n*topShareFraction)))
+		var held float64
+		for _, count := range all[len(all)-top:] {
+			held += float64(count)
+		}
+		dist.TopShare = held / total
+	}
+	dist.Buckets = countBuckets(all)
+	return dist
+}
+
+func countBuckets(sorted []int64) []models.NamedCount {
+	buckets := []models.NamedCount{{Name: "0"}}
+	for lo := int64(1); lo <= sorted[len(sorted)-1]; lo *= 2 {
+		name := strconv.FormatInt(lo, 10)
+		if hi := lo*2 - 1; hi > lo {
+			name += "-" + strconv.FormatInt(hi, 10)
+		}
+		buckets = append(buckets, models.NamedCount{Name: name})
+	}
+	for _, count := range sorted {
+		i := 0
+		if count > 0 {
+			i = 1 + int(math.Floor(math.Log2(float64(count))))
+		}
+		buckets[i].Count++
+	}
+	return buckets
+}
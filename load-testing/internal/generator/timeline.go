@@ -0,0 +1,106 @@
+package generator
+
+import (
+	"hash/fnv"
+	"math"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	interactionWindow = 90 * 24 * time.Hour
+	releaseDecayDays  = 4.0
+)
+
+var hourlyWeights = [24]float64{
+	0.30, 0.20, 0.15, 0.10, 0.10, 0.15, 0.30, 0.50,
+	0.60, 0.60, 0.60, 0.70, 0.80, 0.70, 0.70, 0.70,
+	0.80, 1.00, 1.30, 1.60, 1.80, 1.70, 1.20, 0.60,
+}
+
+var weekdayWeights = [7]float64{
+	time.Sunday:    1.4,
+	time.Monday:    0.9,
+	time.Tuesday:   0.9,
+	time.Wednesday: 1.0,
+	time.Thursday:  1.0,
+	time.Friday:    1.2,
+	time.Saturday:  1.5,
+}
+
+type timeline struct {
+	start time.Time
+	end   time.Time
+	days  int
+}
+
+func newTimeline(end time.Time, window time.Duration) timeline {
+	start := end.Add(-window).UTC().Truncate(24 * time.Hour)
+	return timeline{start: start, end: end, days: int(math.Ceil(end.Sub(start).Hours() / 24))}
+}
+
+func (t timeline) sessionStart(f *gofakeit.Faker) time.Time {
+	maxWeight := 0.0
+	for _, w := range weekdayWeights {
+		maxWeight = max(maxWeight, w)
+	}
+
+	for {
+		day := t.start.AddDate(0, 0, f.Number(0, t.days-1))
+		if unit(f)*maxWeight <= weekdayWeights[day.Weekday()] {
+			if at := t.atTimeOfDay(f, day); at.Before(t.end) {
+				return at
+			}
+		}
+	}
+}
+
+func (t timeline) releaseTime(movie primitive.ObjectID) time.Time {
+	h := fnv.New32a()
+	h.Write(movie[:])
+	return t.start.AddDate(0, 0, int(h.Sum32()%uint32(t.days)))
+}
+
+func (t timeline) afterRelease(f *gofakeit.Faker, release time.Time) time.Time {
+	days := int(-math.Log(1-unit(f)) * releaseDecayDays)
+	at := t.atTimeOfDay(f, release.AddDate(0, 0, days))
+	if at.After(t.end) {
+		return t.sessionStart(f)
+	}
+	return at
+}
+
+func (t timeline) atTimeOfDay(f *gofakeit.Faker, day time.Time) time.Time {
+	offset := time.Duration(pickHour(f))*time.Hour + time.Duration(f.Number(0, 3599))*time.Second
+	return day.Add(offset)
+}
+
+func pickHour(f *gofakeit.Faker) int {
+	total := 0.0
+	for _, w := range hourlyWeights {
+		total += w
+	}
+
+	r := unit(f) * total
+	for hour, w := range hourlyWeights {
+		if r < w {
+			return hour
+		}
+		r -= w
+	}
+	return len(hourlyWeights) - 1
+}
+
+func minutes(f *gofakeit.Faker, lo, hi int) time.Duration {
+	return time.Duration(f.Number(lo*60, hi*60)) * time.Second
+}
+
+func unit(f *gofakeit.Faker) float64 {
+	return f.Float64Range(0, 1)
+}
+
+func chance(f *gofakeit.Faker, p float64) bool {
+	return unit(f) < p
+}
@@ -3,12 +3,11 @@ package generator
 import (
 	"context"
 	"fmt"
-	"time"
+	"strings"
 
 	"github.com/brianvoe/gofakeit/v6"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
-	"golang.org/x/crypto/bcrypt"
 	"load-test/internal/models"
 )
 
@@ -18,62 +17,58 @@ var Genres = []string{
 	"Fantasy", "Crime", "Adventure", "Mystery", "Biography",
 }
 
-func GenerateUsers(ctx context.Context, collection *mongo.Collection, count int) ([]models.User, error) {
+func GenerateUsers(ctx context.Context, collection *mongo.Collection, count int, opts Options) error {
 	fmt.Printf("\n👥 Generating %d users...\n", count)
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("password123"), 10)
+	password, err := opts.Passwords.source()
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	users := make([]models.User, count)
-	batch := make([]interface{}, 0, 1000)
+	return BulkInsert(ctx, collection, opts.Bulk, "users", count, opts.IDs.fakers(kindUser), func(f *gofakeit.Faker, i int) (interface{}, error) {
+		favoriteGenres := pickGenres(f, f.Number(1, 4), nil)
+		dislikedGenres := pickGenres(f, f.Number(0, 3), favoriteGenres)
 
-	for i := 0; i < count; i++ {
-		firstName := gofakeit.FirstName()
-		lastName := gofakeit.LastName()
-
-		favoriteGenres := make([]string, 0)
-		genreCount := gofakeit.Number(1, 4)
-		for j := 0; j < genreCount; j++ {
-			genre := Genres[gofakeit.Number(0, len(Genres)-1)]
-			if !contains(favoriteGenres, genre) {
-				favoriteGenres = append(favoriteGenres, genre)
-			}
-		}
+		user := newUser(f, opts.IDs.UserID(i), opts.IDs.UserBase+i, favoriteGenres, dislikedGenres, opts)
+		var err error
+		user.PlainPassword, user.Password, err = password(f)
+		return user, err
+	})
+}
 
-		user := models.User{
-			ID:        primitive.NewObjectID(),
-			Email:     gofakeit.Email(),
-			Password:  string(hashedPassword),
-			Username:  gofakeit.Username(),
-			FirstName: firstName,
-			LastName:  lastName,
-			Preferences: models.Preferences{
-				FavoriteGenres: favoriteGenres,
-				DislikedGenres: []string{},
-			},
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
+func pickGenres(f *gofakeit.Faker, n int, exclude []string) []string {
+	candidates := make([]string, 0, len(Genres))
+	for _, genre := range Genres {
+		if !contains(exclude, genre) {
+			candidates = append(candidates, genre)
 		}
+	}
+	f.ShuffleStrings(candidates)
+	return candidates[:min(n, len(candidates))]
+}
 
-		users[i] = user
-		batch = append(batch, user)
-
-		if len(batch) == 1000 || i == count-1 {
-			if _, err := collection.InsertMany(ctx, batch); err != nil {
-				return nil, err
-			}
-			batch = batch[:0]
-		}
+func newUser(f *gofakeit.Faker, id primitive.ObjectID, n int, favoriteGenres, dislikedGenres []string, opts Options) models.User {
+	firstName := f.FirstName()
+	lastName := f.LastName()
 
-		if (i+1)%100 == 0 {
-			fmt.Printf("\r   Progress: %d/%d", i+1, count)
-		}
+	return models.User{
+		ID:        id,
+		Email:     uniqueEmail(f, firstName, lastName, n),
+		Username:  fmt.Sprintf("%s%d", f.Username(), n),
+		FirstName: firstName,
+		LastName:  lastName,
+		Preferences: models.Preferences{
+			FavoriteGenres: favoriteGenres,
+			DislikedGenres: dislikedGenres,
+		},
+		CreatedAt:  opts.IDs.Epoch,
+		UpdatedAt:  opts.IDs.Epoch,
+		Generation: opts.Generation,
 	}
+}
 
-	fmt.Printf("\n✅ Created %d users\n", count)
-	return users, nil
+func uniqueEmail(f *gofakeit.Faker, firstName, lastName string, i int) string {
+	return strings.ToLower(fmt.Sprintf("%s.%s.%d@%s", firstName, lastName, i, f.DomainName()))
 }
 
 func contains(slice []string, item string) bool {
@@ -0,0 +1,143 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type VerifyResult struct {
+	Check       string
+	Description string
+	Violations  int64
+	Samples     []bson.M
+}
+
+type verifyCheck struct {
+	name        string
+	description string
+	pipeline    func(collections map[string]string) mongo.Pipeline
+}
+
+var verifyChecks = []verifyCheck{
+	{
+		name:        "duplicates",
+		description: "more than one like, rating, watchlist or purchase for the same user and movie",
+		pipeline: func(map[string]string) mongo.Pipeline {
+			return mongo.Pipeline{
+				{{Key: "$match", Value: bson.M{"type": bson.M{"$ne": "view"}}}},
+				{{Key: "$group", Value: bson.M{
+					"_id":   bson.M{"userId": "$userId", "movieId": "$movieId", "type": "$type"},
+					"count": bson.M{"$sum": 1},
+				}}},
+				{{Key: "$match", Value: bson.M{"count": bson.M{"$gt": 1}}}},
+			}
+		},
+	},
+	{
+		name:        "purchase-without-view",
+		description: "user-movie pairs whose first purchase has no earlier view",
+		pipeline: func(map[string]string) mongo.Pipeline {
+			firstOf := func(interactionType string) bson.M {
+				return bson.M{"$min": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$type", interactionType}}, "$timestamp", nil}}}
+			}
+			return mongo.Pipeline{
+				{{Key: "$match", Value: bson.M{"type": bson.M{"$in": bson.A{"view", "purchase"}}}}},
+				{{Key: "$group", Value: bson.M{
+					"_id":           bson.M{"userId": "$userId", "movieId": "$movieId"},
+					"firstView":     firstOf("view"),
+					"firstPurchase": firstOf("purchase"),
+				}}},
+				{{Key: "$match", Value: bson.M{
+					"firstPurchase": bson.M{"$ne": nil},
+					"$expr": bson.M{"$or": bson.A{
+						bson.M{"$eq": bson.A{"$firstView", nil}},
+						bson.M{"$gt": bson.A{"$firstView", "$firstPurchase"}},
+					}},
+				}}},
+			}
+		},
+	},
+	{
+		name:        "invalid-rating",
+		description: "rating interactions without a rating between 1 and 10",
+		pipeline: func(map[string]string) mongo.Pipeline {
+			return mongo.Pipeline{
+				{{Key: "$match", Value: bson.M{"type": "rating", "$or": bson.A{
+					bson.M{"rating": nil},
+					bson.M{"rating": bson.M{"$lt": 1}},
+					bson.M{"rating": bson.M{"$gt": 10}},
+				}}}},
+				{{Key: "$project", Value: bson.M{"userId": 1, "movieId": 1, "rating": 1}}},
+			}
+		},
+	},
+	{
+		name:        "unknown-user",
+		description: "interactions referencing a user that does not exist",
+		pipeline: func(collections map[string]string) mongo.Pipeline {
+			return danglingPipeline(collections["users"], "userId")
+		},
+	},
+	{
+		name:        "unknown-movie",
+		description: "interactions referencing a movie that does not exist",
+		pipeline: func(collections map[string]string) mongo.Pipeline {
+			return danglingPipeline(collections["movies"], "movieId")
+		},
+	},
+}
+
+func danglingPipeline(from, field string) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$lookup", Value: bson.M{"from": from, "localField": field, "foreignField": "_id", "as": "ref"}}},
+		{{Key: "$match", Value: bson.M{"ref": bson.M{"$size": 0}}}},
+		{{Key: "$project", Value: bson.M{"userId": 1, "movieId": 1, "type": 1}}},
+	}
+}
+
+func VerifyInteractions(ctx context.Context, db *mongo.Database, collections map[string]string, generation string, samples int) ([]VerifyResult, error) {
+	interactions := db.Collection(collections["interactions"])
+	opts := options.Aggregate().SetAllowDiskUse(true)
+
+	var results []VerifyResult
+	for _, check := range verifyChecks {
+		pipeline := check.pipeline(collections)
+		if generation != "" {
+			pipeline = append(mongo.Pipeline{{{Key: "$match", Value: GenerationFilter(generation)}}}, pipeline...)
+		}
+		result := VerifyResult{Check: check.name, Description: check.description}
+
+		counted := append(append(mongo.Pipeline{}, pipeline...), bson.D{{Key: "$count", Value: "violations"}})
+		cursor, err := interactions.Aggregate(ctx, counted, opts)
+		if err != nil {
+			return results, fmt.Errorf("%s: %w", check.name, err)
+		}
+		var counts []struct {
+			Violations int64 `bson:"violations"`
+		}
+		if err := cursor.All(ctx, &counts); err != nil {
+			return results, fmt.Errorf("%s: %w", check.name, err)
+		}
+		if len(counts) > 0 {
+			result.Violations = counts[0].Violations
+		}
+
+		if result.Violations > 0 && samples > 0 {
+			sampled := append(append(mongo.Pipeline{}, pipeline...), bson.D{{Key: "$limit", Value: samples}})
+			cursor, err := interactions.Aggregate(ctx, sampled, opts)
+			if err != nil {
+				return results, fmt.Errorf("%s: %w", check.name, err)
+			}
+			if err := cursor.All(ctx, &result.Samples); err != nil {
+				return results, fmt.Errorf("%s: %w", check.name, err)
+			}
+		}
+
+		results = append(results, result)
+	}
+	return results, nil
+}
@@ -0,0 +1,179 @@
+package capacity
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/montanaflynn/stats"
+	"load-test/internal/config"
+	"load-test/internal/loadtest/executor"
+	"load-test/internal/loadtest/metrics"
+	"load-test/internal/models"
+)
+
+const (
+	StrategyStep   = "step"
+	StrategyBinary = "binary"
+)
+
+type Config struct {
+	Strategy     string
+	StartRate    float64
+	MaxRate      float64
+	StepRate     float64
+	Precision    float64
+	StepDuration time.Duration
+	Warmup       time.Duration
+	MaxDropRate  float64
+}
+
+type StepRunner func(rate float64) models.CapacityStep
+
+func (c Config) Validate() error {
+	switch {
+	case c.Strategy != StrategyStep && c.Strategy != StrategyBinary:
+		return fmt.Errorf("unknown capacity strategy %q (expected step|binary)", c.Strategy)
+	case c.StartRate <= 0:
+		return fmt.Errorf("start rate must be positive, got %g", c.StartRate)
+	case c.MaxRate < c.StartRate:
+		return fmt.Errorf("max rate %g is below start rate %g", c.MaxRate, c.StartRate)
+	case c.Strategy == StrategyStep && c.StepRate <= 0:
+		return fmt.Errorf("rate step must be positive, got %g", c.StepRate)
+	case c.Strategy == StrategyBinary && c.Precision <= 0:
+		return fmt.Errorf("binary search precision must be positive, got %g", c.Precision)
+	case c.Warmup >= c.StepDuration:
+		return fmt.Errorf("warm-up %s must be shorter than the step duration %s", c.Warmup, c.StepDuration)
+	}
+	return nil
+}
+
+func Search(cfg Config, run StepRunner) models.CapacityResult {
+	result := models.CapacityResult{Strategy: cfg.Strategy}
+
+	record := func(rate float64) bool {
+		step := run(rate)
+		result.Steps = append(result.Steps, step)
+		if step.Passed && rate > result.MaxSustainableRate {
+			result.MaxSustainableRate = rate
+		}
+		return step.Passed
+	}
+
+	switch cfg.Strategy {
+	case StrategyBinary:
+		low, high := cfg.StartRate, cfg.MaxRate
+		if !record(low) {
+			break
+		}
+		if record(high) {
+			break
+		}
+		for high-low > cfg.Precision {
+			mid := (low + high) / 2
+			if record(mid) {
+				low = mid
+			} else {
+				high = mid
+			}
+		}
+	default:
+		for rate := cfg.StartRate; rate <= cfg.MaxRate; rate += cfg.StepRate {
+			if !record(rate) {
+				break
+			}
+		}
+	}
+
+	sort.Slice(result.Steps, func(i, j int) bool {
+		return result.Steps[i].TargetRate < result.Steps[j].TargetRate
+	})
+	result.KneeRate = FindKnee(result.Steps)
+
+	return result
+}
+
+func EvaluateStep(rate float64, window []models.Metric, rateResult executor.RateResult, measured time.Duration, slo config.SLOConfig, maxDropRate float64) models.CapacityStep {
+	step := models.CapacityStep{
+		TargetRate:        rate,
+		Requests:          len(window),
+		DroppedIterations: rateResult.Dropped,
+	}
+
+	seconds := measured.Seconds()
+	if seconds <= 0 {
+		seconds = 1
+	}
+
+	if len(window) == 0 {
+		step.Reason = "no requests completed in the measurement window"
+		return step
+	}
+
+	durations := make([]float64, 0, len(window))
+	success := 0
+	for _, m := range window {
+		durations = append(durations, m.Duration.Seconds()*1000)
+		if m.Success {
+			success++
+		}
+	}
+	sort.Float64s(durations)
+
+	step.Throughput = float64(len(window)) / seconds
+	step.SuccessRate = float64(success) / float64(len(window)) * 100
+	step.MedianDuration, _ = stats.Median(durations)
+	step.P95Duration, _ = stats.Percentile(durations, 95)
+	step.P99Duration, _ = stats.Percentile(durations, 99)
+
+	attempted := rateResult.Started + rateResult.Dropped
+	if attempted > 0 {
+		step.AchievedRate = rate * float64(rateResult.Started) / float64(attempted)
+	}
+
+	sloResult := metrics.EvaluateSLO(window, slo).Overall
+	step.Apdex = sloResult.Apdex
+	step.Compliance = sloResult.Compliance
+
+	dropRate := 0.0
+	if attempted > 0 {
+		dropRate = float64(rateResult.Dropped) / float64(attempted) * 100
+	}
+
+	switch {
+	case !sloResult.Met:
+		step.Reason = fmt.Sprintf("SLO compliance %.2f%% below %.2f%%", sloResult.Compliance, sloResult.SuccessObjective)
+	case dropRate > maxDropRate:
+		step.Reason = fmt.Sprintf("%.1f%% of iterations dropped (no idle virtual user)", dropRate)
+	default:
+		step.Passed = true
+	}
+
+	return step
+}
+
+func FindKnee(steps []models.CapacityStep) float64 {
+	if len(steps) < 3 {
+		return 0
+	}
+
+	first, last := steps[0], steps[len(steps)-1]
+	rateSpan := last.TargetRate - first.TargetRate
+	latencySpan := last.P95Duration - first.P95Duration
+	if rateSpan <= 0 || latencySpan <= 0 {
+		return 0
+	}
+
+	knee := 0.0
+	bestDistance := 0.0
+	for _, step := range steps[1 : len(steps)-1] {
+		x := (step.TargetRate - first.TargetRate) / rateSpan
+		y := (step.P95Duration - first.P95Duration) / latencySpan
+		if distance := x - y; distance > bestDistance {
+			bestDistance = distance
+			knee = step.TargetRate
+		}
+	}
+
+	return knee
+}
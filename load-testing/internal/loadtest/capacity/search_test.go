@@ -0,0 +1,110 @@
+package capacity
+
+import (
+	"reflect"
+	"testing"
+
+	"load-test/internal/models"
+)
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       Config
+		limit     float64
+		wantRates []float64
+		wantMax   float64
+	}{
+		{
+			name:      "step stops at the first failure",
+			cfg:       Config{Strategy: StrategyStep, StartRate: 10, MaxRate: 100, StepRate: 10},
+			limit:     35,
+			wantRates: []float64{10, 20, 30, 40},
+			wantMax:   30,
+		},
+		{
+			name:      "step passes every rate",
+			cfg:       Config{Strategy: StrategyStep, StartRate: 10, MaxRate: 30, StepRate: 10},
+			limit:     1000,
+			wantRates: []float64{10, 20, 30},
+			wantMax:   30,
+		},
+		{
+			name:      "step fails at the start rate",
+			cfg:       Config{Strategy: StrategyStep, StartRate: 10, MaxRate: 30, StepRate: 10},
+			limit:     5,
+			wantRates: []float64{10},
+			wantMax:   0,
+		},
+		{
+			name:      "binary narrows to the precision",
+			cfg:       Config{Strategy: StrategyBinary, StartRate: 0, MaxRate: 100, Precision: 10},
+			limit:     30,
+			wantRates: []float64{0, 25, 31.25, 37.5, 50, 100},
+			wantMax:   25,
+		},
+		{
+			name:      "binary passes the max rate",
+			cfg:       Config{Strategy: StrategyBinary, StartRate: 10, MaxRate: 100, Precision: 1},
+			limit:     1000,
+			wantRates: []float64{10, 100},
+			wantMax:   100,
+		},
+		{
+			name:      "binary fails the start rate",
+			cfg:       Config{Strategy: StrategyBinary, StartRate: 10, MaxRate: 100, Precision: 1},
+			limit:     5,
+			wantRates: []float64{10},
+			wantMax:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Search(tt.cfg, func(rate float64) models.CapacityStep {
+				return models.CapacityStep{TargetRate: rate, Passed: rate <= tt.limit}
+			})
+
+			var rates []float64
+			for _, step := range result.Steps {
+				rates = append(rates, step.TargetRate)
+			}
+			if !reflect.DeepEqual(rates, tt.wantRates) {
+				t.Errorf("tried rates %v, want %v", rates, tt.wantRates)
+			}
+			if result.MaxSustainableRate != tt.wantMax {
+				t.Errorf("MaxSustainableRate = %g, want %g", result.MaxSustainableRate, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestFindKnee(t *testing.T) {
+	steps := func(points ...[2]float64) []models.CapacityStep {
+		var steps []models.CapacityStep
+		for _, p := range points {
+			steps = append(steps, models.CapacityStep{TargetRate: p[0], P95Duration: p[1]})
+		}
+		return steps
+	}
+
+	tests := []struct {
+		name  string
+		steps []models.CapacityStep
+		want  float64
+	}{
+		{"too few steps", steps([2]float64{10, 100}, [2]float64{20, 500}), 0},
+		{"linear growth has no knee", steps([2]float64{10, 100}, [2]float64{20, 200}, [2]float64{30, 300}), 0},
+		{"flat latency has no knee", steps([2]float64{10, 100}, [2]float64{20, 100}, [2]float64{30, 100}), 0},
+		{"hockey stick", steps([2]float64{10, 100}, [2]float64{20, 105}, [2]float64{30, 110}, [2]float64{40, 120}, [2]float64{50, 900}), 40},
+		{"early bend", steps([2]float64{10, 100}, [2]float64{20, 110}, [2]float64{30, 600}, [2]float64{40, 800}, [2]float64{50, 1000}), 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FindKnee(tt.steps); got != tt.want {
+				t.Errorf("FindKnee() = %g, want %g", got, tt.want)
+			}
+		})
+	}
+}
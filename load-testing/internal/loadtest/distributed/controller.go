@@ -0,0 +1,297 @@
+package distributed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"load-test/internal/loadtest/metrics"
+)
+
+const startDelay = 2 * time.Second
+
+type WorkerInfo struct {
+	ID       string
+	Name     string
+	Index    int
+	Requests int
+	Reports  int
+	Final    bool
+	LastSeen time.Time
+}
+
+type Controller struct {
+	plan     Plan
+	expected int
+
+	mu        sync.Mutex
+	state     string
+	startAt   time.Time
+	workers   map[string]*WorkerInfo
+	received  map[string]map[int]bool
+	snapshots []Snapshot
+	changed   chan struct{}
+}
+
+func NewController(plan Plan, expectedWorkers int) *Controller {
+	return &Controller{
+		plan:     plan,
+		expected: expectedWorkers,
+		state:    StateWaiting,
+		workers:  make(map[string]*WorkerInfo),
+		received: make(map[string]map[int]bool),
+		changed:  make(chan struct{}, 1),
+	}
+}
+
+func (c *Controller) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/register", c.handleRegister)
+	mux.HandleFunc("/poll", c.handlePoll)
+	mux.HandleFunc("/report", c.handleReport)
+	return mux
+}
+
+func (c *Controller) Serve(ctx context.Context, listener net.Listener) error {
+	server := &http.Server{Handler: c.Handler()}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+
+	if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+func (c *Controller) Run(ctx context.Context, registrationTimeout, drainTimeout time.Duration, progress func(string)) error {
+	deadline := time.After(registrationTimeout)
+	for c.registered() < c.expected {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline:
+			return fmt.Errorf("only %d of %d workers registered within %s", c.registered(), c.expected, registrationTimeout)
+		case <-c.changed:
+		}
+	}
+
+	c.mu.Lock()
+	c.startAt = time.Now().Add(startDelay)
+	c.state = StateRunning
+	startAt := c.startAt
+	c.mu.Unlock()
+
+	progress(fmt.Sprintf("All %d workers registered, starting at %s", c.expected, startAt.Format("15:04:05")))
+
+	total := TotalDuration(c.plan.Stages)
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	timer := time.NewTimer(time.Until(startAt.Add(total)))
+	defer timer.Stop()
+
+running:
+	for {
+		select {
+		case <-ctx.Done():
+			break running
+		case <-timer.C:
+			break running
+		case <-ticker.C:
+			elapsed := time.Since(startAt)
+			if elapsed < 0 {
+				continue
+			}
+			progress(fmt.Sprintf("Elapsed: %s | Target users: %d | Requests: %d",
+				elapsed.Round(time.Second), TargetUsers(c.plan.Stages, elapsed), c.requests()))
+		}
+	}
+
+	c.mu.Lock()
+	c.state = StateStopped
+	c.mu.Unlock()
+
+	progress("Stopping workers, waiting for final reports...")
+
+	drain := time.After(drainTimeout)
+	for !c.allFinal() {
+		select {
+		case <-drain:
+			progress(fmt.Sprintf("Timed out after %s waiting for final reports", drainTimeout))
+			return nil
+		case <-c.changed:
+		}
+	}
+
+	return nil
+}
+
+func (c *Controller) Workers() []WorkerInfo {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	workers := make([]WorkerInfo, len(c.workers))
+	for _, worker := range c.workers {
+		workers[worker.Index] = *worker
+	}
+	return workers
+}
+
+func (c *Controller) Series() []metrics.HistogramSeries {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var merged []metrics.HistogramSeries
+	for _, snapshot := range c.snapshots {
+		merged = append(merged, snapshot.HistogramSeries()...)
+	}
+	return merged
+}
+
+func (c *Controller) Pending() []string {
+	var pending []string
+	for _, worker := range c.Workers() {
+		if !worker.Final {
+			pending = append(pending, worker.ID)
+		}
+	}
+	return pending
+}
+
+func (c *Controller) handleRegister(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req RegisterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c.mu.Lock()
+	if c.state != StateWaiting || len(c.workers) >= c.expected {
+		c.mu.Unlock()
+		http.Error(w, "controller is not accepting workers", http.StatusConflict)
+		return
+	}
+
+	index := len(c.workers)
+	worker := &WorkerInfo{
+		ID:       fmt.Sprintf("worker-%d", index+1),
+		Name:     req.Name,
+		Index:    index,
+		LastSeen: time.Now(),
+	}
+	c.workers[worker.ID] = worker
+	c.received[worker.ID] = make(map[int]bool)
+	c.mu.Unlock()
+	c.notify()
+
+	writeJSON(w, RegisterResponse{WorkerID: worker.ID, Index: index, Plan: c.plan})
+}
+
+func (c *Controller) handlePoll(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	worker, exists := c.workers[r.URL.Query().Get("worker")]
+	if !exists {
+		c.mu.Unlock()
+		http.Error(w, "unknown worker", http.StatusNotFound)
+		return
+	}
+	worker.LastSeen = time.Now()
+
+	resp := PollResponse{State: c.state, StartAt: c.startAt}
+	if c.state == StateRunning {
+		if elapsed := time.Since(c.startAt); elapsed >= 0 {
+			resp.Users = WorkerShare(TargetUsers(c.plan.Stages, elapsed), c.expected, worker.Index)
+		}
+	}
+	c.mu.Unlock()
+
+	writeJSON(w, resp)
+}
+
+func (c *Controller) handleReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var snapshot Snapshot
+	if err := json.NewDecoder(r.Body).Decode(&snapshot); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c.mu.Lock()
+	worker, exists := c.workers[snapshot.WorkerID]
+	if !exists {
+		c.mu.Unlock()
+		http.Error(w, "unknown worker", http.StatusNotFound)
+		return
+	}
+	worker.LastSeen = time.Now()
+	if c.received[worker.ID][snapshot.Seq] {
+		c.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	c.received[worker.ID][snapshot.Seq] = true
+	worker.Reports++
+	worker.Requests += snapshot.Requests()
+	worker.Final = worker.Final || snapshot.Final
+	c.snapshots = append(c.snapshots, snapshot)
+	c.mu.Unlock()
+	c.notify()
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *Controller) registered() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.workers)
+}
+
+func (c *Controller) requests() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	total := 0
+	for _, worker := range c.workers {
+		total += worker.Requests
+	}
+	return total
+}
+
+func (c *Controller) allFinal() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, worker := range c.workers {
+		if !worker.Final {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Controller) notify() {
+	select {
+	case c.changed <- struct{}{}:
+	default:
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,107 @@
+package distributed
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	StateWaiting = "waiting"
+	StateRunning = "running"
+	StateStopped = "stopped"
+)
+
+type Stage struct {
+	Duration time.Duration `json:"duration"`
+	Users    int           `json:"users"`
+}
+
+type Plan struct {
+	TargetURL      string        `json:"targetUrl"`
+	Scenario       string        `json:"scenario"`
+	Stages         []Stage       `json:"stages"`
+	ReportInterval time.Duration `json:"reportInterval"`
+}
+
+type RegisterRequest struct {
+	Name string `json:"name"`
+}
+
+type RegisterResponse struct {
+	WorkerID string `json:"workerId"`
+	Index    int    `json:"index"`
+	Plan     Plan   `json:"plan"`
+}
+
+type PollResponse struct {
+	State   string    `json:"state"`
+	StartAt time.Time `json:"startAt"`
+	Users   int       `json:"users"`
+}
+
+func ParseStages(value string) ([]Stage, error) {
+	var stages []Stage
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		durationStr, usersStr, found := strings.Cut(part, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid stage %q (expected duration:users)", part)
+		}
+
+		duration, err := time.ParseDuration(durationStr)
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("invalid stage duration %q", durationStr)
+		}
+
+		users, err := strconv.Atoi(usersStr)
+		if err != nil || users < 0 {
+			return nil, fmt.Errorf("invalid stage users %q", usersStr)
+		}
+
+		stages = append(stages, Stage{Duration: duration, Users: users})
+	}
+
+	if len(stages) == 0 {
+		return nil, fmt.Errorf("no stages defined")
+	}
+	return stages, nil
+}
+
+func TotalDuration(stages []Stage) time.Duration {
+	var total time.Duration
+	for _, stage := range stages {
+		total += stage.Duration
+	}
+	return total
+}
+
+func TargetUsers(stages []Stage, elapsed time.Duration) int {
+	previous := 0
+	for _, stage := range stages {
+		if elapsed < stage.Duration {
+			progress := float64(elapsed) / float64(stage.Duration)
+			return int(math.Round(float64(previous) + float64(stage.Users-previous)*progress))
+		}
+		elapsed -= stage.Duration
+		previous = stage.Users
+	}
+	return 0
+}
+
+func WorkerShare(total, workers, index int) int {
+	if workers <= 0 {
+		return 0
+	}
+	share := total / workers
+	if index < total%workers {
+		share++
+	}
+	return share
+}
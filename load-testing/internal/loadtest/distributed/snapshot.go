@@ -0,0 +1,126 @@
+package distributed
+
+import (
+	"sync"
+	"time"
+
+	"load-test/internal/loadtest/metrics"
+	"load-test/internal/models"
+)
+
+const (
+	snapshotBucketsPerDecade = 100
+	maxSeriesSamples         = 3
+)
+
+type SeriesKey struct {
+	Scenario   string `json:"scenario"`
+	Endpoint   string `json:"endpoint"`
+	Method     string `json:"method"`
+	StatusCode int    `json:"statusCode"`
+	Success    bool   `json:"success"`
+	Error      string `json:"error,omitempty"`
+}
+
+type Series struct {
+	Key       SeriesKey          `json:"key"`
+	Histogram *metrics.Histogram `json:"histogram"`
+	Samples   []string           `json:"samples,omitempty"`
+}
+
+type Snapshot struct {
+	WorkerID string    `json:"workerId"`
+	Seq      int       `json:"seq"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Final    bool      `json:"final"`
+	Series   []Series  `json:"series"`
+}
+
+type Aggregator struct {
+	mu     sync.Mutex
+	seq    int
+	start  time.Time
+	series map[SeriesKey]*Series
+}
+
+func NewAggregator() *Aggregator {
+	return &Aggregator{
+		start:  time.Now(),
+		series: make(map[SeriesKey]*Series),
+	}
+}
+
+func (a *Aggregator) Add(m models.Metric) {
+	key := SeriesKey{
+		Scenario:   m.Scenario,
+		Endpoint:   m.Endpoint,
+		Method:     m.Method,
+		StatusCode: m.StatusCode,
+		Success:    m.Success,
+		Error:      m.Error,
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	series, exists := a.series[key]
+	if !exists {
+		series = &Series{Key: key, Histogram: metrics.NewHistogram(snapshotBucketsPerDecade)}
+		a.series[key] = series
+	}
+
+	series.Histogram.Add(m.Duration.Seconds() * 1000)
+	if m.ResponseBody != "" && len(series.Samples) < maxSeriesSamples {
+		series.Samples = append(series.Samples, m.ResponseBody)
+	}
+}
+
+func (a *Aggregator) Flush(workerID string) Snapshot {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := time.Now()
+	a.seq++
+	snapshot := Snapshot{
+		WorkerID: workerID,
+		Seq:      a.seq,
+		Start:    a.start,
+		End:      now,
+		Series:   make([]Series, 0, len(a.series)),
+	}
+	for _, series := range a.series {
+		snapshot.Series = append(snapshot.Series, *series)
+	}
+
+	a.start = now
+	a.series = make(map[SeriesKey]*Series)
+	return snapshot
+}
+
+func (s Snapshot) Requests() int {
+	total := 0
+	for _, series := range s.Series {
+		total += series.Histogram.Total
+	}
+	return total
+}
+
+func (s Snapshot) HistogramSeries() []metrics.HistogramSeries {
+	series := make([]metrics.HistogramSeries, 0, len(s.Series))
+	for _, entry := range s.Series {
+		series = append(series, metrics.HistogramSeries{
+			Start:      s.Start,
+			End:        s.End,
+			Scenario:   entry.Key.Scenario,
+			Endpoint:   entry.Key.Endpoint,
+			Method:     entry.Key.Method,
+			StatusCode: entry.Key.StatusCode,
+			Success:    entry.Key.Success,
+			Error:      entry.Key.Error,
+			Samples:    entry.Samples,
+			Histogram:  entry.Histogram,
+		})
+	}
+	return series
+}
@@ -0,0 +1,208 @@
+package distributed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"load-test/internal/loadtest/executor"
+	"load-test/internal/models"
+)
+
+const (
+	pollInterval        = time.Second
+	workerIDOffset      = 1000000
+	finalReportAttempts = 5
+)
+
+type Worker struct {
+	controllerURL string
+	name          string
+	httpClient    *http.Client
+
+	id    string
+	index int
+	plan  Plan
+}
+
+func NewWorker(controllerURL, name string) *Worker {
+	return &Worker{
+		controllerURL: strings.TrimSuffix(controllerURL, "/"),
+		name:          name,
+		httpClient:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (w *Worker) Register(ctx context.Context, retryFor time.Duration) error {
+	deadline := time.Now().Add(retryFor)
+	for {
+		var resp RegisterResponse
+		err := w.do(ctx, http.MethodPost, "/register", RegisterRequest{Name: w.name}, &resp)
+		if err == nil {
+			w.id, w.index, w.plan = resp.WorkerID, resp.Index, resp.Plan
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("failed to register with controller %s: %w", w.controllerURL, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+func (w *Worker) ID() string {
+	return w.id
+}
+
+func (w *Worker) Plan() Plan {
+	return w.plan
+}
+
+func (w *Worker) Run(ctx context.Context, progress func(string)) error {
+	metricsChan := make(chan models.Metric, 10000)
+	aggregator := NewAggregator()
+
+	var drained sync.WaitGroup
+	drained.Add(1)
+	go func() {
+		defer drained.Done()
+		for metric := range metricsChan {
+			aggregator.Add(metric)
+		}
+	}()
+
+	scaler := executor.NewScaler(w.plan.TargetURL, w.plan.Scenario, (w.index+1)*workerIDOffset, metricsChan)
+
+	reportInterval := w.plan.ReportInterval
+	if reportInterval <= 0 {
+		reportInterval = 2 * time.Second
+	}
+	reportTicker := time.NewTicker(reportInterval)
+	defer reportTicker.Stop()
+
+	pollTicker := time.NewTicker(pollInterval)
+	defer pollTicker.Stop()
+
+	started := false
+	var pending []Snapshot
+	var runErr error
+
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			runErr = ctx.Err()
+			break loop
+		case <-reportTicker.C:
+			if !started {
+				continue
+			}
+			var err error
+			if pending, err = w.sendPending(ctx, append(pending, aggregator.Flush(w.id))); err != nil {
+				progress(fmt.Sprintf("report failed, %d snapshots queued for retry: %v", len(pending), err))
+			}
+		case <-pollTicker.C:
+			var poll PollResponse
+			if err := w.do(ctx, http.MethodGet, "/poll?worker="+url.QueryEscape(w.id), nil, &poll); err != nil {
+				progress(fmt.Sprintf("poll failed: %v", err))
+				continue
+			}
+
+			switch poll.State {
+			case StateRunning:
+				if !started {
+					if wait := time.Until(poll.StartAt); wait > 0 {
+						time.Sleep(wait)
+					}
+					aggregator.Flush(w.id)
+					started = true
+					progress("started")
+				}
+				if scaler.Active() != poll.Users {
+					scaler.SetUsers(poll.Users)
+					progress(fmt.Sprintf("users: %d", poll.Users))
+				}
+			case StateStopped:
+				break loop
+			}
+		}
+	}
+
+	scaler.Stop()
+	close(metricsChan)
+	drained.Wait()
+
+	final := aggregator.Flush(w.id)
+	final.Final = true
+	pending = append(pending, final)
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		if pending, err = w.sendPending(context.Background(), pending); err == nil {
+			break
+		}
+		if attempt == finalReportAttempts {
+			return fmt.Errorf("failed to send final report (%d snapshots unsent): %w", len(pending), err)
+		}
+		progress(fmt.Sprintf("final report failed, retrying: %v", err))
+		time.Sleep(time.Duration(attempt) * time.Second)
+	}
+
+	return runErr
+}
+
+func (w *Worker) sendPending(ctx context.Context, pending []Snapshot) ([]Snapshot, error) {
+	for len(pending) > 0 {
+		if err := w.do(ctx, http.MethodPost, "/report", pending[0], nil); err != nil {
+			return pending, err
+		}
+		pending = pending[1:]
+	}
+	return nil, nil
+}
+
+func (w *Worker) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reqBody *bytes.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	} else {
+		reqBody = bytes.NewReader(nil)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, w.controllerURL+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		var msg bytes.Buffer
+		msg.ReadFrom(resp.Body)
+		return fmt.Errorf("controller returned %d: %s", resp.StatusCode, strings.TrimSpace(msg.String()))
+	}
+
+	if out != nil {
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package executor
+
+import (
+	"fmt"
+	"sync"
+
+	"load-test/internal/loadtest/client"
+	"load-test/internal/loadtest/scenarios"
+	"load-test/internal/models"
+)
+
+type VirtualUser struct {
+	ID     int
+	Client *client.HTTPClient
+	Email  string
+}
+
+type Pool struct {
+	idle chan *VirtualUser
+	size int
+}
+
+func NewPool(baseURL string, size int, metricsChan chan<- models.Metric) (*Pool, error) {
+	pool := &Pool{idle: make(chan *VirtualUser, size)}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	sem := make(chan struct{}, 20)
+
+	for i := 0; i < size; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(userID int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			httpClient := client.NewHTTPClient(baseURL)
+			email, err := scenarios.SetupUser(httpClient, userID, metricsChan)
+			if err != nil {
+				return
+			}
+
+			mu.Lock()
+			pool.size++
+			mu.Unlock()
+			pool.idle <- &VirtualUser{ID: userID, Client: httpClient, Email: email}
+		}(i)
+	}
+
+	wg.Wait()
+
+	if pool.size == 0 {
+		return nil, fmt.Errorf("failed to register any of %d virtual users", size)
+	}
+
+	return pool, nil
+}
+
+func (p *Pool) Size() int {
+	return p.size
+}
+
+func (p *Pool) TryAcquire() (*VirtualUser, bool) {
+	select {
+	case vu := <-p.idle:
+		return vu, true
+	default:
+		return nil, false
+	}
+}
+
+func (p *Pool) Release(vu *VirtualUser) {
+	p.idle <- vu
+}
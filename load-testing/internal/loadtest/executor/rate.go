@@ -0,0 +1,55 @@
+package executor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"load-test/internal/loadtest/scenarios"
+	"load-test/internal/models"
+)
+
+type RateResult struct {
+	Started int
+	Dropped int
+}
+
+func RunArrivalRate(ctx context.Context, pool *Pool, rate float64, duration time.Duration, scenario string, metricsChan chan<- models.Metric) RateResult {
+	var result RateResult
+	if rate <= 0 {
+		return result
+	}
+
+	var wg sync.WaitGroup
+	interval := time.Duration(float64(time.Second) / rate)
+	start := time.Now()
+	deadline := start.Add(duration)
+
+	for next := start; next.Before(deadline); next = next.Add(interval) {
+		if wait := time.Until(next); wait > 0 {
+			select {
+			case <-ctx.Done():
+				wg.Wait()
+				return result
+			case <-time.After(wait):
+			}
+		}
+
+		vu, ok := pool.TryAcquire()
+		if !ok {
+			result.Dropped++
+			continue
+		}
+
+		result.Started++
+		wg.Add(1)
+		go func(vu *VirtualUser) {
+			defer wg.Done()
+			defer pool.Release(vu)
+			scenarios.RunScenario(vu.Client, scenario, vu.Email, metricsChan)
+		}(vu)
+	}
+
+	wg.Wait()
+	return result
+}
@@ -0,0 +1,89 @@
+package executor
+
+import (
+	"sync"
+	"time"
+
+	"load-test/internal/loadtest/client"
+	"load-test/internal/loadtest/scenarios"
+	"load-test/internal/models"
+)
+
+type Scaler struct {
+	baseURL     string
+	scenario    string
+	idOffset    int
+	metricsChan chan<- models.Metric
+
+	mu     sync.Mutex
+	stops  []chan struct{}
+	nextID int
+	wg     sync.WaitGroup
+}
+
+func RunVirtualUser(baseURL string, userID int, scenario string, metricsChan chan<- models.Metric, stopChan <-chan struct{}) {
+	httpClient := client.NewHTTPClient(baseURL)
+
+	email, err := scenarios.SetupUser(httpClient, userID, metricsChan)
+	if err != nil {
+		return
+	}
+
+	iterationDelay := time.Duration(100+userID%1000*5) * time.Millisecond
+
+	for {
+		select {
+		case <-stopChan:
+			return
+		default:
+			scenarios.RunScenario(httpClient, scenario, email, metricsChan)
+
+			time.Sleep(iterationDelay)
+		}
+	}
+}
+
+func NewScaler(baseURL, scenario string, idOffset int, metricsChan chan<- models.Metric) *Scaler {
+	return &Scaler{
+		baseURL:     baseURL,
+		scenario:    scenario,
+		idOffset:    idOffset,
+		metricsChan: metricsChan,
+	}
+}
+
+func (s *Scaler) SetUsers(users int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for len(s.stops) < users {
+		stop := make(chan struct{})
+		s.stops = append(s.stops, stop)
+
+		userID := s.idOffset + s.nextID
+		s.nextID++
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			RunVirtualUser(s.baseURL, userID, s.scenario, s.metricsChan, stop)
+		}()
+	}
+
+	for len(s.stops) > users && len(s.stops) > 0 {
+		last := len(s.stops) - 1
+		close(s.stops[last])
+		s.stops = s.stops[:last]
+	}
+}
+
+func (s *Scaler) Active() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.stops)
+}
+
+func (s *Scaler) Stop() {
+	s.SetUsers(0)
+	s.wg.Wait()
+}
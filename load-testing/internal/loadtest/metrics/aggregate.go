@@ -0,0 +1,316 @@
+package metrics
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"load-test/internal/config"
+	"load-test/internal/models"
+)
+
+var AggregatedCSVHeader = []string{
+	"scenario",
+	"endpoint",
+	"method",
+	"status_code",
+	"success",
+	"error",
+	"count",
+	"mean_ms",
+	"p50_ms",
+	"p95_ms",
+	"p99_ms",
+	"max_ms",
+}
+
+type HistogramSeries struct {
+	Start      time.Time
+	End        time.Time
+	Scenario   string
+	Endpoint   string
+	Method     string
+	StatusCode int
+	Success    bool
+	Error      string
+	Samples    []string
+	Histogram  *Histogram
+}
+
+type histogramGroup struct {
+	histogram   *Histogram
+	success     int
+	statusCodes map[int]int
+	categories  map[string]int
+}
+
+func newHistogramGroup(bucketsPerDecade int) *histogramGroup {
+	return &histogramGroup{
+		histogram:   NewHistogram(bucketsPerDecade),
+		statusCodes: make(map[int]int),
+		categories:  make(map[string]int),
+	}
+}
+
+func (g *histogramGroup) add(s HistogramSeries, category string) {
+	g.histogram.Merge(s.Histogram)
+	g.statusCodes[s.StatusCode] += s.Histogram.Total
+	if s.Success {
+		g.success += s.Histogram.Total
+	} else {
+		g.categories[category] += s.Histogram.Total
+	}
+}
+
+func (g *histogramGroup) successRate() float64 {
+	return float64(g.success) / float64(g.histogram.Total) * 100
+}
+
+func CalculateHistogramStats(series []HistogramSeries) models.TestStats {
+	series = nonEmptySeries(series)
+	if len(series) == 0 {
+		return models.TestStats{}
+	}
+
+	bucketsPerDecade := series[0].Histogram.BucketsPerDecade
+	overall := newHistogramGroup(bucketsPerDecade)
+	byScenario := make(map[string]*histogramGroup)
+	byEndpoint := make(map[string]*histogramGroup)
+	errorCounts := make(map[string]int)
+	errorSamples := make(map[string][]string)
+
+	minTime, maxTime := series[0].Start, series[0].End
+	for _, s := range series {
+		key := models.Metric{StatusCode: s.StatusCode, Success: s.Success, Error: s.Error}
+		category := ClassifyError(key)
+
+		overall.add(s, category)
+		if _, exists := byScenario[s.Scenario]; !exists {
+			byScenario[s.Scenario] = newHistogramGroup(bucketsPerDecade)
+		}
+		byScenario[s.Scenario].add(s, category)
+		if _, exists := byEndpoint[s.Endpoint]; !exists {
+			byEndpoint[s.Endpoint] = newHistogramGroup(bucketsPerDecade)
+		}
+		byEndpoint[s.Endpoint].add(s, category)
+
+		if !s.Success {
+			errorCounts[ErrorKey(key)] += s.Histogram.Total
+			for _, sample := range s.Samples {
+				if len(errorSamples[category]) < maxErrorSamples && !containsSample(errorSamples[category], sample) {
+					errorSamples[category] = append(errorSamples[category], sample)
+				}
+			}
+		}
+
+		if s.Start.Before(minTime) {
+			minTime = s.Start
+		}
+		if s.End.After(maxTime) {
+			maxTime = s.End
+		}
+	}
+
+	totalRequests := overall.histogram.Total
+	failureCount := totalRequests - overall.success
+
+	testDuration := maxTime.Sub(minTime).Seconds()
+	if testDuration == 0 {
+		testDuration = 1
+	}
+
+	scenarios := make(map[string]models.ScenarioStats)
+	for scenario, group := range byScenario {
+		minIdx, maxIdx := group.histogram.Range()
+		scenarios[scenario] = models.ScenarioStats{
+			Count:       group.histogram.Total,
+			SuccessRate: group.successRate(),
+			AvgDuration: histogramMean(group.histogram),
+			MinDuration: group.histogram.BucketValue(minIdx),
+			MaxDuration: group.histogram.BucketValue(maxIdx),
+		}
+	}
+
+	endpoints := make(map[string]models.EndpointStats)
+	for endpoint, group := range byEndpoint {
+		endpoints[endpoint] = models.EndpointStats{
+			Count:           group.histogram.Total,
+			SuccessRate:     group.successRate(),
+			AvgDuration:     histogramMean(group.histogram),
+			P95Duration:     group.histogram.Percentile(95),
+			StatusCodes:     group.statusCodes,
+			ErrorCategories: group.categories,
+		}
+	}
+
+	minIdx, maxIdx := overall.histogram.Range()
+	return models.TestStats{
+		TotalRequests:     totalRequests,
+		SuccessCount:      overall.success,
+		FailureCount:      failureCount,
+		SuccessRate:       overall.successRate(),
+		FailureRate:       float64(failureCount) / float64(totalRequests) * 100,
+		RequestsPerSecond: float64(totalRequests) / testDuration,
+		MinDuration:       overall.histogram.BucketValue(minIdx),
+		MaxDuration:       overall.histogram.BucketValue(maxIdx),
+		MeanDuration:      histogramMean(overall.histogram),
+		MedianDuration:    overall.histogram.Percentile(50),
+		P95Duration:       overall.histogram.Percentile(95),
+		P99Duration:       overall.histogram.Percentile(99),
+		ByScenario:        scenarios,
+		ByEndpoint:        endpoints,
+		Errors:            errorCounts,
+		StatusCodes:       overall.statusCodes,
+		ErrorCategories:   overall.categories,
+		ErrorSamples:      errorSamples,
+	}
+}
+
+func EvaluateHistogramSLO(series []HistogramSeries, slo config.SLOConfig) models.SLOReport {
+	report := models.SLOReport{ByEndpoint: make(map[string]models.SLOResult)}
+	series = nonEmptySeries(series)
+	if len(series) == 0 {
+		return report
+	}
+
+	sort.SliceStable(series, func(i, j int) bool { return series[i].Start.Before(series[j].Start) })
+	start := series[0].Start
+
+	overall := newSLOCounter(slo.Default, start)
+	byEndpoint := make(map[string]*sloCounter)
+
+	for _, s := range series {
+		counter, exists := byEndpoint[s.Endpoint]
+		if !exists {
+			counter = newSLOCounter(slo.For(s.Endpoint), start)
+			byEndpoint[s.Endpoint] = counter
+		}
+
+		for _, c := range []*sloCounter{counter, overall} {
+			satisfied, tolerating := classifyHistogram(s, c.objective.LatencyTarget)
+			c.addCounts(s.Start, s.Histogram.Total, satisfied, tolerating)
+		}
+	}
+
+	report.Overall = overall.result("overall")
+	for endpoint, counter := range byEndpoint {
+		report.ByEndpoint[endpoint] = counter.result(endpoint)
+	}
+
+	return report
+}
+
+func classifyHistogram(s HistogramSeries, target time.Duration) (int, int) {
+	if !s.Success {
+		return 0, 0
+	}
+
+	targetMs := target.Seconds() * 1000
+	satisfied, tolerating := 0, 0
+	for idx, count := range s.Histogram.Counts {
+		value := s.Histogram.BucketValue(idx)
+		switch {
+		case value <= targetMs:
+			satisfied += count
+		case value <= 4*targetMs:
+			tolerating += count
+		}
+	}
+	return satisfied, tolerating
+}
+
+func histogramMean(h *Histogram) float64 {
+	if h.Total == 0 {
+		return 0
+	}
+
+	sum := 0.0
+	for idx, count := range h.Counts {
+		sum += h.BucketValue(idx) * float64(count)
+	}
+	return sum / float64(h.Total)
+}
+
+func nonEmptySeries(series []HistogramSeries) []HistogramSeries {
+	kept := make([]HistogramSeries, 0, len(series))
+	for _, s := range series {
+		if s.Histogram != nil && s.Histogram.Total > 0 {
+			kept = append(kept, s)
+		}
+	}
+	return kept
+}
+
+func SaveHistogramCSV(filename string, series []HistogramSeries) error {
+	type seriesKey struct {
+		scenario, endpoint, method string
+		statusCode                 int
+		success                    bool
+		err                        string
+	}
+
+	merged := make(map[seriesKey]*Histogram)
+	var keys []seriesKey
+	for _, s := range nonEmptySeries(series) {
+		key := seriesKey{s.Scenario, s.Endpoint, s.Method, s.StatusCode, s.Success, s.Error}
+		if _, exists := merged[key]; !exists {
+			merged[key] = NewHistogram(s.Histogram.BucketsPerDecade)
+			keys = append(keys, key)
+		}
+		merged[key].Merge(s.Histogram)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.endpoint != b.endpoint {
+			return a.endpoint < b.endpoint
+		}
+		if a.method != b.method {
+			return a.method < b.method
+		}
+		if a.scenario != b.scenario {
+			return a.scenario < b.scenario
+		}
+		if a.statusCode != b.statusCode {
+			return a.statusCode < b.statusCode
+		}
+		return a.err < b.err
+	})
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write(AggregatedCSVHeader); err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		h := merged[key]
+		_, maxIdx := h.Range()
+		record := []string{
+			key.scenario,
+			key.endpoint,
+			key.method,
+			fmt.Sprintf("%d", key.statusCode),
+			fmt.Sprintf("%t", key.success),
+			key.err,
+			fmt.Sprintf("%d", h.Total),
+			fmt.Sprintf("%.2f", histogramMean(h)),
+			fmt.Sprintf("%.2f", h.Percentile(50)),
+			fmt.Sprintf("%.2f", h.Percentile(95)),
+			fmt.Sprintf("%.2f", h.Percentile(99)),
+			fmt.Sprintf("%.2f", h.BucketValue(maxIdx)),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
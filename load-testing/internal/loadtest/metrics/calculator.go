@@ -18,6 +18,9 @@ func CalculateStats(metrics []models.Metric) models.TestStats {
 	scenarioMetrics := make(map[string][]models.Metric)
 	endpointMetrics := make(map[string][]models.Metric)
 	errorCounts := make(map[string]int)
+	statusCodes := make(map[int]int)
+	errorCategories := make(map[string]int)
+	errorSamples := make(map[string][]string)
 
 	var minTime, maxTime time.Time
 
@@ -25,10 +28,18 @@ func CalculateStats(metrics []models.Metric) models.TestStats {
 		durationMs := m.Duration.Seconds() * 1000
 		durations = append(durations, durationMs)
 
+		statusCodes[m.StatusCode]++
+
 		if m.Success {
 			successCount++
-		} else if m.Error != "" {
-			errorCounts[m.Error]++
+		} else {
+			errorCounts[ErrorKey(m)]++
+
+			category := ClassifyError(m)
+			errorCategories[category]++
+			if m.ResponseBody != "" && len(errorSamples[category]) < maxErrorSamples && !containsSample(errorSamples[category], m.ResponseBody) {
+				errorSamples[category] = append(errorSamples[category], m.ResponseBody)
+			}
 		}
 
 		scenarioMetrics[m.Scenario] = append(scenarioMetrics[m.Scenario], m)
@@ -91,11 +102,16 @@ func CalculateStats(metrics []models.Metric) models.TestStats {
 	for endpoint, epMetrics := range endpointMetrics {
 		var epDurations []float64
 		epSuccess := 0
+		epStatusCodes := make(map[int]int)
+		epErrorCategories := make(map[string]int)
 
 		for _, m := range epMetrics {
 			epDurations = append(epDurations, m.Duration.Seconds()*1000)
+			epStatusCodes[m.StatusCode]++
 			if m.Success {
 				epSuccess++
+			} else {
+				epErrorCategories[ClassifyError(m)]++
 			}
 		}
 
@@ -104,10 +120,12 @@ func CalculateStats(metrics []models.Metric) models.TestStats {
 		p95Duration, _ := stats.Percentile(epDurations, 95)
 
 		byEndpoint[endpoint] = models.EndpointStats{
-			Count:       len(epMetrics),
-			SuccessRate: float64(epSuccess) / float64(len(epMetrics)) * 100,
-			AvgDuration: avgDuration,
-			P95Duration: p95Duration,
+			Count:           len(epMetrics),
+			SuccessRate:     float64(epSuccess) / float64(len(epMetrics)) * 100,
+			AvgDuration:     avgDuration,
+			P95Duration:     p95Duration,
+			StatusCodes:     epStatusCodes,
+			ErrorCategories: epErrorCategories,
 		}
 	}
 
@@ -127,5 +145,17 @@ func CalculateStats(metrics []models.Metric) models.TestStats {
 		ByScenario:        byScenario,
 		ByEndpoint:        byEndpoint,
 		Errors:            errorCounts,
+		StatusCodes:       statusCodes,
+		ErrorCategories:   errorCategories,
+		ErrorSamples:      errorSamples,
+	}
+}
+
+func containsSample(samples []string, sample string) bool {
+	for _, s := range samples {
+		if s == sample {
+			return true
+		}
 	}
+	return false
 }
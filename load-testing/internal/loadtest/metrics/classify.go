@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+
+	"load-test/internal/models"
+)
+
+const (
+	ErrorTimeout           = "timeout"
+	ErrorConnectionRefused = "connection_refused"
+	ErrorConnectionReset   = "connection_reset"
+	ErrorDNS               = "dns"
+	ErrorTransport         = "transport"
+	ErrorAssertion         = "assertion_failure"
+
+	maxErrorSamples = 3
+)
+
+func ClassifyError(m models.Metric) string {
+	if m.Success {
+		return ""
+	}
+
+	if m.Error != "" && m.StatusCode == 0 {
+		return classifyTransportError(m.Error)
+	}
+
+	switch {
+	case m.StatusCode >= 500:
+		return fmt.Sprintf("5xx:%d", m.StatusCode)
+	case m.StatusCode >= 400:
+		return fmt.Sprintf("4xx:%d", m.StatusCode)
+	case m.StatusCode >= 300:
+		return fmt.Sprintf("3xx:%d", m.StatusCode)
+	default:
+		return ErrorAssertion
+	}
+}
+
+func ErrorKey(m models.Metric) string {
+	switch {
+	case m.Error != "":
+		return m.Error
+	case m.StatusCode >= 300:
+		return fmt.Sprintf("HTTP %d", m.StatusCode)
+	default:
+		return fmt.Sprintf("unexpected response (HTTP %d)", m.StatusCode)
+	}
+}
+
+func classifyTransportError(message string) string {
+	message = strings.ToLower(message)
+
+	switch {
+	case strings.Contains(message, "timeout"), strings.Contains(message, "deadline exceeded"):
+		return ErrorTimeout
+	case strings.Contains(message, "connection refused"):
+		return ErrorConnectionRefused
+	case strings.Contains(message, "connection reset"), strings.Contains(message, "broken pipe"), strings.Contains(message, "eof"):
+		return ErrorConnectionReset
+	case strings.Contains(message, "no such host"), strings.Contains(message, "server misbehaving"), strings.Contains(message, "lookup "):
+		return ErrorDNS
+	default:
+		return ErrorTransport
+	}
+}
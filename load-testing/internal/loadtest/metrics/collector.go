@@ -4,12 +4,14 @@ import (
 	"encoding/csv"
 	"fmt"
 	"os"
+	"sync"
 	"time"
 
 	"load-test/internal/models"
 )
 
 type Collector struct {
+	mu      sync.Mutex
 	metrics []models.Metric
 }
 
@@ -20,13 +22,30 @@ func NewCollector() *Collector {
 }
 
 func (c *Collector) Add(metric models.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.metrics = append(c.metrics, metric)
 }
 
 func (c *Collector) GetMetrics() []models.Metric {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	return c.metrics
 }
 
+func (c *Collector) Between(from, to time.Time) []models.Metric {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var window []models.Metric
+	for _, m := range c.metrics {
+		if !m.Timestamp.Before(from) && m.Timestamp.Before(to) {
+			window = append(window, m)
+		}
+	}
+	return window
+}
+
 func (c *Collector) SaveToCSV(filename string) error {
 	file, err := os.Create(filename)
 	if err != nil {
@@ -46,13 +65,14 @@ func (c *Collector) SaveToCSV(filename string) error {
 		"duration_ms",
 		"success",
 		"error",
+		"response_body",
 	}
 
 	if err := writer.Write(header); err != nil {
 		return err
 	}
 
-	for _, m := range c.metrics {
+	for _, m := range c.GetMetrics() {
 		record := []string{
 			m.Timestamp.Format(time.RFC3339),
 			m.Scenario,
@@ -62,6 +82,7 @@ func (c *Collector) SaveToCSV(filename string) error {
 			fmt.Sprintf("%.2f", m.Duration.Seconds()*1000),
 			fmt.Sprintf("%t", m.Success),
 			m.Error,
+			m.ResponseBody,
 		}
 
 		if err := writer.Write(record); err != nil {
@@ -73,12 +94,12 @@ func (c *Collector) SaveToCSV(filename string) error {
 }
 
 func (c *Collector) Count() int {
-	return len(c.metrics)
+	return len(c.GetMetrics())
 }
 
 func (c *Collector) SuccessCount() int {
 	count := 0
-	for _, m := range c.metrics {
+	for _, m := range c.GetMetrics() {
 		if m.Success {
 			count++
 		}
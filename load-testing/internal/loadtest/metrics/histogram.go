@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+)
+
+const histogramMinValue = 0.01
+
+type Histogram struct {
+	BucketsPerDecade int         `json:"bucketsPerDecade"`
+	Counts           map[int]int `json:"counts"`
+	Total            int         `json:"total"`
+}
+
+func NewHistogram(bucketsPerDecade int) *Histogram {
+	return &Histogram{
+		BucketsPerDecade: bucketsPerDecade,
+		Counts:           make(map[int]int),
+	}
+}
+
+func (h *Histogram) Add(valueMs float64) {
+	h.AddN(valueMs, 1)
+}
+
+func (h *Histogram) AddN(valueMs float64, n int) {
+	h.Counts[h.BucketIndex(valueMs)] += n
+	h.Total += n
+}
+
+func (h *Histogram) BucketIndex(valueMs float64) int {
+	if valueMs < histogramMinValue {
+		valueMs = histogramMinValue
+	}
+	return int(math.Floor(math.Log10(valueMs/histogramMinValue) * float64(h.BucketsPerDecade)))
+}
+
+func (h *Histogram) BucketBounds(index int) (float64, float64) {
+	lower := histogramMinValue * math.Pow(10, float64(index)/float64(h.BucketsPerDecade))
+	upper := histogramMinValue * math.Pow(10, float64(index+1)/float64(h.BucketsPerDecade))
+	return lower, upper
+}
+
+func (h *Histogram) BucketValue(index int) float64 {
+	lower, upper := h.BucketBounds(index)
+	return math.Sqrt(lower * upper)
+}
+
+func (h *Histogram) Range() (int, int) {
+	first := true
+	minIdx, maxIdx := 0, 0
+	for idx := range h.Counts {
+		if first || idx < minIdx {
+			minIdx = idx
+		}
+		if first || idx > maxIdx {
+			maxIdx = idx
+		}
+		first = false
+	}
+	return minIdx, maxIdx
+}
+
+func (h *Histogram) Merge(other *Histogram) {
+	for idx, count := range other.Counts {
+		h.Counts[idx] += count
+	}
+	h.Total += other.Total
+}
+
+func (h *Histogram) Percentile(percent float64) float64 {
+	if h.Total == 0 {
+		return 0
+	}
+
+	indices := make([]int, 0, len(h.Counts))
+	for idx := range h.Counts {
+		indices = append(indices, idx)
+	}
+	sort.Ints(indices)
+
+	target := int(math.Ceil(percent / 100 * float64(h.Total)))
+	if target < 1 {
+		target = 1
+	}
+
+	seen := 0
+	for _, idx := range indices {
+		seen += h.Counts[idx]
+		if seen >= target {
+			return h.BucketValue(idx)
+		}
+	}
+
+	return h.BucketValue(indices[len(indices)-1])
+}
@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHistogramPercentile(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []float64
+		percent float64
+		want    float64
+	}{
+		{"empty", nil, 50, 0},
+		{"single value", []float64{100}, 99, 100},
+		{"median of uniform", []float64{10, 20, 30, 40, 50, 60, 70, 80, 90, 100}, 50, 50},
+		{"p90 of uniform", []float64{10, 20, 30, 40, 50, 60, 70, 80, 90, 100}, 90, 90},
+		{"p100 is the max", []float64{1, 5, 250}, 100, 250},
+		{"p0 is the min", []float64{1, 5, 250}, 0, 1},
+		{"below the floor", []float64{0, 0.001}, 50, histogramMinValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHistogram(100)
+			for _, v := range tt.values {
+				h.Add(v)
+			}
+			got := h.Percentile(tt.percent)
+			if !withinBucket(got, tt.want, h.BucketsPerDecade) {
+				t.Errorf("Percentile(%g) = %g, want %g (within one bucket)", tt.percent, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHistogramMerge(t *testing.T) {
+	tests := []struct {
+		name      string
+		a, b      []float64
+		wantTotal int
+		wantP50   float64
+		wantMax   float64
+	}{
+		{"into empty", nil, []float64{10, 20, 30}, 3, 20, 30},
+		{"from empty", []float64{10, 20, 30}, nil, 3, 20, 30},
+		{"disjoint ranges", []float64{1, 2, 3}, []float64{100, 200, 300}, 6, 3, 300},
+		{"overlapping buckets", []float64{50, 50}, []float64{50, 500}, 4, 50, 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := NewHistogram(100), NewHistogram(100)
+			for _, v := range tt.a {
+				a.Add(v)
+			}
+			for _, v := range tt.b {
+				b.Add(v)
+			}
+			a.Merge(b)
+
+			if a.Total != tt.wantTotal {
+				t.Errorf("Total = %d, want %d", a.Total, tt.wantTotal)
+			}
+			sum := 0
+			for _, count := range a.Counts {
+				sum += count
+			}
+			if sum != a.Total {
+				t.Errorf("bucket counts sum to %d, Total is %d", sum, a.Total)
+			}
+			if got := a.Percentile(50); !withinBucket(got, tt.wantP50, 100) {
+				t.Errorf("Percentile(50) = %g, want %g", got, tt.wantP50)
+			}
+			if _, maxIdx := a.Range(); !withinBucket(a.BucketValue(maxIdx), tt.wantMax, 100) {
+				t.Errorf("max = %g, want %g", a.BucketValue(maxIdx), tt.wantMax)
+			}
+		})
+	}
+}
+
+func withinBucket(got, want float64, bucketsPerDecade int) bool {
+	if want == 0 {
+		return got == 0
+	}
+	return math.Abs(math.Log10(got/want)) <= 1/float64(bucketsPerDecade)
+}
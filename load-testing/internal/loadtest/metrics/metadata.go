@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	"load-test/internal/models"
+	"load-test/internal/secret"
+)
+
+func MetadataPath(csvPath string) string {
+	return strings.TrimSuffix(csvPath, ".csv") + ".meta.json"
+}
+
+func SaveMetadata(filename string, metadata models.RunMetadata) error {
+	if metadata.Soak != nil {
+		soak := *metadata.Soak
+		soak.Resources = make([]models.ResourceSample, len(metadata.Soak.Resources))
+		for i, sample := range metadata.Soak.Resources {
+			sample.HealthError = secret.Redact(sample.HealthError)
+			soak.Resources[i] = sample
+		}
+		metadata.Soak = &soak
+	}
+
+	data, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
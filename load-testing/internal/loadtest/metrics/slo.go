@@ -0,0 +1,197 @@
+package metrics
+
+import (
+	"sort"
+	"time"
+
+	"load-test/internal/config"
+	"load-test/internal/models"
+)
+
+type sloCounter struct {
+	objective  config.Objective
+	start      time.Time
+	total      int
+	good       int
+	satisfied  int
+	tolerating int
+	windows    map[int64]*windowCounter
+}
+
+type windowCounter struct {
+	total int
+	bad   int
+}
+
+func EvaluateSLO(metrics []models.Metric, slo config.SLOConfig) models.SLOReport {
+	report := models.SLOReport{ByEndpoint: make(map[string]models.SLOResult)}
+	if len(metrics) == 0 {
+		return report
+	}
+
+	start := metrics[0].Timestamp
+	for _, m := range metrics {
+		if m.Timestamp.Before(start) {
+			start = m.Timestamp
+		}
+	}
+
+	overall := newSLOCounter(slo.Default, start)
+	byEndpoint := make(map[string]*sloCounter)
+
+	for _, m := range metrics {
+		counter, exists := byEndpoint[m.Endpoint]
+		if !exists {
+			counter = newSLOCounter(slo.For(m.Endpoint), start)
+			byEndpoint[m.Endpoint] = counter
+		}
+
+		satisfied, tolerating := classifyApdex(m, counter.objective.LatencyTarget)
+		counter.add(m.Timestamp, satisfied, tolerating)
+
+		satisfied, tolerating = classifyApdex(m, overall.objective.LatencyTarget)
+		overall.add(m.Timestamp, satisfied, tolerating)
+	}
+
+	report.Overall = overall.result("overall")
+	for endpoint, counter := range byEndpoint {
+		report.ByEndpoint[endpoint] = counter.result(endpoint)
+	}
+
+	return report
+}
+
+func ApdexRating(apdex float64) string {
+	switch {
+	case apdex >= 0.94:
+		return "Excellent"
+	case apdex >= 0.85:
+		return "Good"
+	case apdex >= 0.70:
+		return "Fair"
+	case apdex >= 0.50:
+		return "Poor"
+	default:
+		return "Unacceptable"
+	}
+}
+
+func ApdexGrade(apdex float64) string {
+	switch {
+	case apdex >= 0.94:
+		return "A"
+	case apdex >= 0.85:
+		return "B"
+	case apdex >= 0.70:
+		return "C"
+	case apdex >= 0.50:
+		return "D"
+	default:
+		return "F"
+	}
+}
+
+func classifyApdex(m models.Metric, target time.Duration) (bool, bool) {
+	if !m.Success {
+		return false, false
+	}
+	if m.Duration <= target {
+		return true, false
+	}
+	return false, m.Duration <= 4*target
+}
+
+func newSLOCounter(objective config.Objective, start time.Time) *sloCounter {
+	if objective.Window <= 0 {
+		objective.Window = time.Minute
+	}
+	return &sloCounter{
+		objective: objective,
+		start:     start,
+		windows:   make(map[int64]*windowCounter),
+	}
+}
+
+func (c *sloCounter) add(timestamp time.Time, satisfied, tolerating bool) {
+	switch {
+	case satisfied:
+		c.addCounts(timestamp, 1, 1, 0)
+	case tolerating:
+		c.addCounts(timestamp, 1, 0, 1)
+	default:
+		c.addCounts(timestamp, 1, 0, 0)
+	}
+}
+
+func (c *sloCounter) addCounts(timestamp time.Time, total, satisfied, tolerating int) {
+	c.total += total
+	c.satisfied += satisfied
+	c.good += satisfied
+	c.tolerating += tolerating
+
+	slot := int64(timestamp.Sub(c.start) / c.objective.Window)
+	w, exists := c.windows[slot]
+	if !exists {
+		w = &windowCounter{}
+		c.windows[slot] = w
+	}
+	w.total += total
+	w.bad += total - satisfied
+}
+
+func (c *sloCounter) result(name string) models.SLOResult {
+	allowedBadRatio := (100 - c.objective.SuccessObjective) / 100
+
+	result := models.SLOResult{
+		Name:             name,
+		LatencyTargetMs:  c.objective.LatencyTarget.Seconds() * 1000,
+		SuccessObjective: c.objective.SuccessObjective,
+		WindowSeconds:    c.objective.Window.Seconds(),
+		Total:            c.total,
+		Good:             c.good,
+		Satisfied:        c.satisfied,
+		Tolerating:       c.tolerating,
+		Frustrated:       c.total - c.satisfied - c.tolerating,
+	}
+
+	if c.total == 0 {
+		return result
+	}
+
+	result.Apdex = (float64(c.satisfied) + float64(c.tolerating)/2) / float64(c.total)
+	result.Rating = ApdexRating(result.Apdex)
+	result.Compliance = float64(c.good) / float64(c.total) * 100
+	result.Met = result.Compliance >= c.objective.SuccessObjective
+	result.ErrorBudget = float64(c.total) * allowedBadRatio
+
+	bad := float64(c.total - c.good)
+	if result.ErrorBudget > 0 {
+		result.BudgetConsumed = bad / result.ErrorBudget * 100
+	} else if bad > 0 {
+		result.BudgetConsumed = 100
+	}
+	result.BudgetRemaining = 100 - result.BudgetConsumed
+
+	slots := make([]int64, 0, len(c.windows))
+	for slot := range c.windows {
+		slots = append(slots, slot)
+	}
+	sort.Slice(slots, func(i, j int) bool { return slots[i] < slots[j] })
+
+	for _, slot := range slots {
+		w := c.windows[slot]
+		burnRate := 0.0
+		if allowedBadRatio > 0 {
+			burnRate = float64(w.bad) / float64(w.total) / allowedBadRatio
+		}
+		if burnRate > result.MaxBurnRate {
+			result.MaxBurnRate = burnRate
+		}
+		result.BurnRate = append(result.BurnRate, models.BurnRatePoint{
+			Timestamp: c.start.Add(time.Duration(slot) * c.objective.Window),
+			BurnRate:  burnRate,
+		})
+	}
+
+	return result
+}
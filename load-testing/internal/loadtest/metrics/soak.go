@@ -0,0 +1,209 @@
+package metrics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/montanaflynn/stats"
+	"load-test/internal/models"
+)
+
+const (
+	TrendP95            = "p95_ms"
+	TrendErrorRate      = "error_rate"
+	TrendThroughput     = "throughput"
+	TrendResidentMemory = "resident_mb"
+	TrendHealthLatency  = "health_latency_ms"
+)
+
+type SoakConfig struct {
+	Window              time.Duration
+	Step                time.Duration
+	DriftThreshold      float64
+	ErrorDriftThreshold float64
+}
+
+type soakBucket struct {
+	durations []float64
+	failures  int
+}
+
+type soakWindow struct {
+	start  time.Time
+	length time.Duration
+	bucket soakBucket
+}
+
+func AnalyzeSoak(metrics []models.Metric, resources []models.ResourceSample, cfg SoakConfig) models.SoakReport {
+	step := cfg.Step
+	if step <= 0 || step > cfg.Window {
+		step = cfg.Window
+	}
+
+	report := models.SoakReport{
+		WindowSeconds:       cfg.Window.Seconds(),
+		StepSeconds:         step.Seconds(),
+		DriftThreshold:      cfg.DriftThreshold,
+		ErrorDriftThreshold: cfg.ErrorDriftThreshold,
+		ByEndpoint:          make(map[string]models.SoakSeries),
+		Resources:           resources,
+	}
+	if len(metrics) == 0 || cfg.Window <= 0 {
+		return report
+	}
+
+	start, end := metrics[0].Timestamp, metrics[0].Timestamp
+	for _, m := range metrics {
+		if m.Timestamp.Before(start) {
+			start = m.Timestamp
+		}
+		if m.Timestamp.After(end) {
+			end = m.Timestamp
+		}
+	}
+
+	stepCount := int(end.Sub(start)/step) + 1
+	lastStep := end.Sub(start.Add(time.Duration(stepCount-1) * step))
+	if stepCount > 1 && lastStep < step/2 {
+		stepCount--
+		lastStep = step
+	}
+
+	overall := make([]soakBucket, stepCount)
+	byEndpoint := make(map[string][]soakBucket)
+
+	for _, m := range metrics {
+		idx := int(m.Timestamp.Sub(start) / step)
+		if idx >= stepCount {
+			continue
+		}
+
+		buckets, exists := byEndpoint[m.Endpoint]
+		if !exists {
+			buckets = make([]soakBucket, stepCount)
+			byEndpoint[m.Endpoint] = buckets
+		}
+
+		durationMs := m.Duration.Seconds() * 1000
+		overall[idx].durations = append(overall[idx].durations, durationMs)
+		buckets[idx].durations = append(buckets[idx].durations, durationMs)
+		if !m.Success {
+			overall[idx].failures++
+			buckets[idx].failures++
+		}
+	}
+
+	stepLength := func(idx int) time.Duration {
+		if idx == stepCount-1 {
+			return lastStep
+		}
+		return step
+	}
+	stepsPerWindow := min(max(int(cfg.Window/step), 1), stepCount)
+	rolling := func(buckets []soakBucket) []soakWindow {
+		windows := make([]soakWindow, 0, stepCount-stepsPerWindow+1)
+		for first := 0; first+stepsPerWindow <= stepCount; first++ {
+			window := soakWindow{start: start.Add(time.Duration(first) * step)}
+			for idx := first; idx < first+stepsPerWindow; idx++ {
+				window.length += stepLength(idx)
+				window.bucket.durations = append(window.bucket.durations, buckets[idx].durations...)
+				window.bucket.failures += buckets[idx].failures
+			}
+			windows = append(windows, window)
+		}
+		return windows
+	}
+
+	report.Overall = soakSeries("overall", start, rolling(overall), stepsPerWindow, cfg)
+	report.Drifting = report.Overall.Drifting
+	for endpoint, buckets := range byEndpoint {
+		series := soakSeries(endpoint, start, rolling(buckets), stepsPerWindow, cfg)
+		report.ByEndpoint[endpoint] = series
+		report.Drifting = report.Drifting || series.Drifting
+	}
+
+	report.ResourceTrends = resourceTrends(start, resources, cfg)
+	for _, trend := range report.ResourceTrends {
+		report.Drifting = report.Drifting || trend.Drifting
+	}
+
+	return report
+}
+
+func soakSeries(name string, start time.Time, windows []soakWindow, overlap int, cfg SoakConfig) models.SoakSeries {
+	series := models.SoakSeries{Name: name}
+
+	var hours, p95s, errorRates, throughputs []float64
+	for _, w := range windows {
+		bucket := w.bucket
+		if len(bucket.durations) == 0 {
+			continue
+		}
+
+		sort.Float64s(bucket.durations)
+
+		window := models.SoakWindow{
+			Start:      w.start,
+			Requests:   len(bucket.durations),
+			Throughput: float64(len(bucket.durations)) / w.length.Seconds(),
+			ErrorRate:  float64(bucket.failures) / float64(len(bucket.durations)) * 100,
+		}
+		window.MedianDuration, _ = stats.Median(bucket.durations)
+		window.P95Duration, _ = stats.Percentile(bucket.durations, 95)
+		series.Windows = append(series.Windows, window)
+
+		hours = append(hours, w.start.Add(w.length/2).Sub(start).Hours())
+		p95s = append(p95s, window.P95Duration)
+		errorRates = append(errorRates, window.ErrorRate)
+		throughputs = append(throughputs, window.Throughput)
+	}
+
+	p95 := FitRollingTrend(TrendP95, hours, p95s, overlap)
+	p95.Drifting = p95.Significant && p95.ChangePerHour > cfg.DriftThreshold
+
+	errorRate := FitRollingTrend(TrendErrorRate, hours, errorRates, overlap)
+	errorRate.Drifting = errorRate.Significant && errorRate.SlopePerHour > cfg.ErrorDriftThreshold
+
+	throughput := FitRollingTrend(TrendThroughput, hours, throughputs, overlap)
+	throughput.Drifting = throughput.Significant && throughput.ChangePerHour < -cfg.DriftThreshold
+
+	series.Trends = []models.TrendResult{p95, errorRate, throughput}
+	for _, trend := range series.Trends {
+		series.Drifting = series.Drifting || trend.Drifting
+	}
+
+	return series
+}
+
+func resourceTrends(start time.Time, resources []models.ResourceSample, cfg SoakConfig) []models.TrendResult {
+	if len(resources) == 0 {
+		return nil
+	}
+
+	var memHours, memory, healthHours, healthLatency []float64
+	for _, sample := range resources {
+		elapsed := sample.Timestamp.Sub(start).Hours()
+		if sample.ResidentMB > 0 {
+			memHours = append(memHours, elapsed)
+			memory = append(memory, sample.ResidentMB)
+		}
+		if sample.HealthStatus > 0 {
+			healthHours = append(healthHours, elapsed)
+			healthLatency = append(healthLatency, sample.HealthLatencyMs)
+		}
+	}
+
+	var trends []models.TrendResult
+	if len(memory) > 0 {
+		trend := FitTrend(TrendResidentMemory, memHours, memory)
+		trend.Drifting = trend.Significant && trend.ChangePerHour > cfg.DriftThreshold
+		trends = append(trends, trend)
+	}
+	if len(healthLatency) > 0 {
+		trend := FitTrend(TrendHealthLatency, healthHours, healthLatency)
+		trend.Drifting = trend.Significant && trend.ChangePerHour > cfg.DriftThreshold
+		trends = append(trends, trend)
+	}
+
+	return trends
+}
@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"load-test/internal/models"
+)
+
+func TestAnalyzeSoakWindows(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var hour []models.Metric
+	for i := 0; i < 3600; i++ {
+		hour = append(hour, models.Metric{
+			Timestamp: start.Add(time.Duration(i) * time.Second),
+			Endpoint:  "/movies",
+			Success:   true,
+			Duration:  time.Duration(100+i/60) * time.Millisecond,
+		})
+	}
+
+	tests := []struct {
+		name           string
+		metrics        []models.Metric
+		window, step   time.Duration
+		wantWindows    int
+		wantRequests   int
+		wantThroughput float64
+	}{
+		{"fixed windows when step is unset", hour, 5 * time.Minute, 0, 12, 300, 1},
+		{"rolling windows", hour, 5 * time.Minute, time.Minute, 56, 300, 1},
+		{"step equal to window", hour, 10 * time.Minute, 10 * time.Minute, 6, 600, 1},
+		{"shorter than one window", hour[:100], 5 * time.Minute, time.Minute, 1, 100, 100.0 / 99},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := AnalyzeSoak(tt.metrics, nil, SoakConfig{Window: tt.window, Step: tt.step, DriftThreshold: 10, ErrorDriftThreshold: 1})
+			windows := report.Overall.Windows
+			if len(windows) != tt.wantWindows {
+				t.Fatalf("got %d windows, want %d", len(windows), tt.wantWindows)
+			}
+			if windows[0].Requests != tt.wantRequests {
+				t.Errorf("first window has %d requests, want %d", windows[0].Requests, tt.wantRequests)
+			}
+			if got := windows[0].Throughput; got < tt.wantThroughput*0.99 || got > tt.wantThroughput*1.01 {
+				t.Errorf("first window throughput = %g, want %g", got, tt.wantThroughput)
+			}
+			if tt.step > 0 && len(windows) > 1 && windows[1].Start.Sub(windows[0].Start) != tt.step {
+				t.Errorf("windows advance by %s, want %s", windows[1].Start.Sub(windows[0].Start), tt.step)
+			}
+		})
+	}
+}
+
+func TestAnalyzeSoakDrift(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	series := func(latency func(i int) time.Duration) []models.Metric {
+		var metrics []models.Metric
+		for i := 0; i < 7200; i++ {
+			metrics = append(metrics, models.Metric{
+				Timestamp: start.Add(time.Duration(i) * time.Second),
+				Endpoint:  "/movies",
+				Success:   true,
+				Duration:  latency(i),
+			})
+		}
+		return metrics
+	}
+
+	tests := []struct {
+		name         string
+		metrics      []models.Metric
+		wantDrifting bool
+	}{
+		{"steady latency", series(func(i int) time.Duration { return 100 * time.Millisecond }), false},
+		{"latency growing 50% per hour", series(func(i int) time.Duration { return time.Duration(100+i*50/3600) * time.Millisecond }), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := AnalyzeSoak(tt.metrics, nil, SoakConfig{Window: 5 * time.Minute, Step: time.Minute, DriftThreshold: 10, ErrorDriftThreshold: 1})
+			if report.Drifting != tt.wantDrifting {
+				t.Errorf("Drifting = %t, want %t (trends %+v)", report.Drifting, tt.wantDrifting, report.Overall.Trends)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"math"
+
+	"load-test/internal/models"
+)
+
+var tCritical95 = []float64{
+	12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+func FitTrend(metric string, hours, values []float64) models.TrendResult {
+	trend := models.TrendResult{Metric: metric, Samples: len(values)}
+	n := float64(len(values))
+	if len(values) < 3 || len(hours) != len(values) {
+		return trend
+	}
+
+	var meanX, meanY float64
+	for i := range values {
+		meanX += hours[i]
+		meanY += values[i]
+	}
+	meanX /= n
+	meanY /= n
+
+	var sxx, sxy, sst float64
+	for i := range values {
+		dx, dy := hours[i]-meanX, values[i]-meanY
+		sxx += dx * dx
+		sxy += dx * dy
+		sst += dy * dy
+	}
+	if sxx == 0 {
+		return trend
+	}
+
+	slope := sxy / sxx
+	intercept := meanY - slope*meanX
+
+	var ssres float64
+	for i := range values {
+		residual := values[i] - (intercept + slope*hours[i])
+		ssres += residual * residual
+	}
+
+	trend.Baseline = intercept
+	trend.SlopePerHour = slope
+	if sst > 0 {
+		trend.RSquared = 1 - ssres/sst
+	}
+
+	reference := intercept
+	if reference <= 0 {
+		reference = meanY
+	}
+	if reference > 0 {
+		trend.ChangePerHour = slope / reference * 100
+	}
+
+	df := len(values) - 2
+	stdErr := math.Sqrt(ssres / float64(df) / sxx)
+	if stdErr > 0 {
+		trend.TStat = slope / stdErr
+		trend.Significant = df >= 2 && math.Abs(trend.TStat) > tCritical(df)
+	} else {
+		trend.Significant = df >= 2 && slope != 0
+	}
+
+	return trend
+}
+
+// FitRollingTrend fits values taken from windows that each overlap the next
+// overlap-1 ones. Neighbouring points share most of their requests, so the
+// significance test only counts one in overlap as independent.
+func FitRollingTrend(metric string, hours, values []float64, overlap int) models.TrendResult {
+	trend := FitTrend(metric, hours, values)
+	if overlap <= 1 || len(values) < 3 {
+		return trend
+	}
+
+	df := len(values)/overlap - 2
+	trend.TStat /= math.Sqrt(float64(overlap))
+	switch {
+	case df < 2:
+		trend.Significant = false
+	case trend.TStat != 0:
+		trend.Significant = math.Abs(trend.TStat) > tCritical(df)
+	}
+	return trend
+}
+
+func tCritical(df int) float64 {
+	if df <= len(tCritical95) {
+		return tCritical95[df-1]
+	}
+	return 1.96 + 2.4/float64(df)
+}
@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFitTrend(t *testing.T) {
+	tests := []struct {
+		name            string
+		hours, values   []float64
+		wantSlope       float64
+		wantChange      float64
+		wantSignificant bool
+	}{
+		{"too few points", []float64{0, 1}, []float64{100, 200}, 0, 0, false},
+		{"mismatched lengths", []float64{0, 1, 2}, []float64{1, 2}, 0, 0, false},
+		{"no spread in time", []float64{1, 1, 1}, []float64{1, 2, 3}, 0, 0, false},
+		{"flat", []float64{0, 1, 2, 3, 4}, []float64{100, 100, 100, 100, 100}, 0, 0, false},
+		{"exact growth", []float64{0, 1, 2, 3, 4}, []float64{100, 110, 120, 130, 140}, 10, 10, true},
+		{"exact decay", []float64{0, 1, 2, 3, 4}, []float64{200, 180, 160, 140, 120}, -20, -10, true},
+		{"noise around a flat line", []float64{0, 1, 2, 3, 4, 5}, []float64{100, 103, 98, 101, 97, 102}, -0.14, -0.14, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FitTrend(TrendP95, tt.hours, tt.values)
+			if math.Abs(got.SlopePerHour-tt.wantSlope) > 0.01 {
+				t.Errorf("SlopePerHour = %g, want %g", got.SlopePerHour, tt.wantSlope)
+			}
+			if math.Abs(got.ChangePerHour-tt.wantChange) > 0.01 {
+				t.Errorf("ChangePerHour = %g, want %g", got.ChangePerHour, tt.wantChange)
+			}
+			if got.Significant != tt.wantSignificant {
+				t.Errorf("Significant = %t, want %t (t = %g)", got.Significant, tt.wantSignificant, got.TStat)
+			}
+		})
+	}
+}
+
+func TestFitRollingTrend(t *testing.T) {
+	hours := []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}
+	values := []float64{100, 104, 99, 105, 101, 106, 102, 108, 103, 107, 104, 109}
+
+	tests := []struct {
+		name            string
+		overlap         int
+		wantSignificant bool
+	}{
+		{"independent windows", 1, true},
+		{"heavily overlapping windows", 5, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FitRollingTrend(TrendP95, hours, values, tt.overlap)
+			if got.Significant != tt.wantSignificant {
+				t.Errorf("Significant = %t, want %t (t = %g)", got.Significant, tt.wantSignificant, got.TStat)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"load-test/internal/models"
+)
+
+type Poller struct {
+	healthURL  string
+	interval   time.Duration
+	httpClient *http.Client
+	mongo      *mongo.Client
+
+	mu      sync.Mutex
+	samples []models.ResourceSample
+}
+
+type serverStatus struct {
+	Mem struct {
+		Resident float64 `bson:"resident"`
+		Virtual  float64 `bson:"virtual"`
+	} `bson:"mem"`
+	Connections struct {
+		Current int `bson:"current"`
+	} `bson:"connections"`
+}
+
+func NewPoller(healthURL string, interval time.Duration) *Poller {
+	return &Poller{
+		healthURL:  healthURL,
+		interval:   interval,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *Poller) ConnectMongo(ctx context.Context, uri string) error {
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		return fmt.Errorf("failed to connect to MongoDB: %w", err)
+	}
+
+	if err := client.Ping(ctx, nil); err != nil {
+		client.Disconnect(ctx)
+		return fmt.Errorf("failed to ping MongoDB: %w", err)
+	}
+
+	p.mongo = client
+	return nil
+}
+
+func (p *Poller) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	p.poll(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.poll(ctx)
+		}
+	}
+}
+
+func (p *Poller) Samples() []models.ResourceSample {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	samples := make([]models.ResourceSample, len(p.samples))
+	copy(samples, p.samples)
+	return samples
+}
+
+func (p *Poller) Close(ctx context.Context) {
+	if p.mongo != nil {
+		p.mongo.Disconnect(ctx)
+	}
+}
+
+func (p *Poller) poll(ctx context.Context) {
+	sample := models.ResourceSample{Timestamp: time.Now()}
+
+	if p.healthURL != "" {
+		p.pollHealth(ctx, &sample)
+	}
+	if p.mongo != nil {
+		p.pollMongo(ctx, &sample)
+	}
+
+	p.mu.Lock()
+	p.samples = append(p.samples, sample)
+	p.mu.Unlock()
+}
+
+func (p *Poller) pollHealth(ctx context.Context, sample *models.ResourceSample) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.healthURL, nil)
+	if err != nil {
+		sample.HealthError = err.Error()
+		return
+	}
+
+	start := time.Now()
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		sample.HealthError = err.Error()
+		return
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	sample.HealthLatencyMs = time.Since(start).Seconds() * 1000
+	sample.HealthStatus = resp.StatusCode
+}
+
+func (p *Poller) pollMongo(ctx context.Context, sample *models.ResourceSample) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var status serverStatus
+	err := p.mongo.Database("admin").RunCommand(ctx, bson.D{{Key: "serverStatus", Value: 1}}).Decode(&status)
+	if err != nil {
+		return
+	}
+
+	sample.ResidentMB = status.Mem.Resident
+	sample.VirtualMB = status.Mem.Virtual
+	sample.Connections = status.Connections.Current
+}
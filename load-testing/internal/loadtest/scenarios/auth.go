@@ -1,6 +1,7 @@
 package scenarios
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -8,10 +9,16 @@ import (
 	"time"
 
 	"github.com/brianvoe/gofakeit/v6"
+	"load-test/internal/config"
 	"load-test/internal/loadtest/client"
 	"load-test/internal/models"
+	"load-test/internal/secret"
 )
 
+const maxResponseSample = 512
+
+var Password = config.DefaultPassword
+
 type RegisterRequest struct {
 	Email     string `json:"email"`
 	Password  string `json:"password"`
@@ -42,7 +49,7 @@ func Register(httpClient *client.HTTPClient, userID int, metricsChan chan<- mode
 
 	reqBody := RegisterRequest{
 		Email:     email,
-		Password:  "password123",
+		Password:  Password,
 		Username:  username,
 		FirstName: firstName,
 		LastName:  lastName,
@@ -53,42 +60,43 @@ func Register(httpClient *client.HTTPClient, userID int, metricsChan chan<- mode
 	success := err == nil && resp.StatusCode == 201
 	errorMsg := ""
 	if err != nil {
-		errorMsg = err.Error()
+		errorMsg = secret.Redact(err.Error())
+	}
+	sample := getResponseSample(resp)
+
+	var authResp AuthResponse
+	if success {
+		if sample, err = decodeResponse(resp, &authResp); err != nil {
+			success, errorMsg = false, err.Error()
+		}
+	}
+	if resp != nil {
+		resp.Body.Close()
 	}
 
 	metricsChan <- models.Metric{
-		Timestamp:  time.Now(),
-		Scenario:   "auth",
-		Endpoint:   "/auth/register",
-		Method:     "POST",
-		StatusCode: getStatusCode(resp),
-		Duration:   duration,
-		Success:    success,
-		Error:      errorMsg,
+		Timestamp:    time.Now(),
+		Scenario:     "auth",
+		Endpoint:     "/auth/register",
+		Method:       "POST",
+		StatusCode:   getStatusCode(resp),
+		Duration:     duration,
+		Success:      success,
+		Error:        errorMsg,
+		ResponseBody: sample,
 	}
 
 	if !success {
-		if resp != nil {
-			resp.Body.Close()
-		}
 		return "", "", fmt.Errorf("registration failed")
 	}
 
-	defer resp.Body.Close()
-	body, _ := io.ReadAll(resp.Body)
-
-	var authResp AuthResponse
-	if err := json.Unmarshal(body, &authResp); err != nil {
-		return "", "", err
-	}
-
 	return authResp.Token, email, nil
 }
 
 func Login(httpClient *client.HTTPClient, email string, metricsChan chan<- models.Metric) (string, error) {
 	reqBody := LoginRequest{
 		Email:    email,
-		Password: "password123",
+		Password: Password,
 	}
 
 	resp, duration, err := httpClient.Post("/auth/login", reqBody)
@@ -96,35 +104,36 @@ func Login(httpClient *client.HTTPClient, email string, metricsChan chan<- model
 	success := err == nil && resp.StatusCode == 200
 	errorMsg := ""
 	if err != nil {
-		errorMsg = err.Error()
+		errorMsg = secret.Redact(err.Error())
+	}
+	sample := getResponseSample(resp)
+
+	var authResp AuthResponse
+	if success {
+		if sample, err = decodeResponse(resp, &authResp); err != nil {
+			success, errorMsg = false, err.Error()
+		}
+	}
+	if resp != nil {
+		resp.Body.Close()
 	}
 
 	metricsChan <- models.Metric{
-		Timestamp:  time.Now(),
-		Scenario:   "auth",
-		Endpoint:   "/auth/login",
-		Method:     "POST",
-		StatusCode: getStatusCode(resp),
-		Duration:   duration,
-		Success:    success,
-		Error:      errorMsg,
+		Timestamp:    time.Now(),
+		Scenario:     "auth",
+		Endpoint:     "/auth/login",
+		Method:       "POST",
+		StatusCode:   getStatusCode(resp),
+		Duration:     duration,
+		Success:      success,
+		Error:        errorMsg,
+		ResponseBody: sample,
 	}
 
 	if !success {
-		if resp != nil {
-			resp.Body.Close()
-		}
 		return "", fmt.Errorf("login failed")
 	}
 
-	defer resp.Body.Close()
-	body, _ := io.ReadAll(resp.Body)
-
-	var authResp AuthResponse
-	if err := json.Unmarshal(body, &authResp); err != nil {
-		return "", err
-	}
-
 	return authResp.Token, nil
 }
 
@@ -134,18 +143,19 @@ func GetProfile(httpClient *client.HTTPClient, metricsChan chan<- models.Metric)
 	success := err == nil && resp.StatusCode == 200
 	errorMsg := ""
 	if err != nil {
-		errorMsg = err.Error()
+		errorMsg = secret.Redact(err.Error())
 	}
 
 	metricsChan <- models.Metric{
-		Timestamp:  time.Now(),
-		Scenario:   "auth",
-		Endpoint:   "/auth/me",
-		Method:     "GET",
-		StatusCode: getStatusCode(resp),
-		Duration:   duration,
-		Success:    success,
-		Error:      errorMsg,
+		Timestamp:    time.Now(),
+		Scenario:     "auth",
+		Endpoint:     "/auth/me",
+		Method:       "GET",
+		StatusCode:   getStatusCode(resp),
+		Duration:     duration,
+		Success:      success,
+		Error:        errorMsg,
+		ResponseBody: getResponseSample(resp),
 	}
 
 	if resp != nil {
@@ -161,3 +171,28 @@ func getStatusCode(resp *http.Response) int {
 	}
 	return resp.StatusCode
 }
+
+func getResponseSample(resp *http.Response) string {
+	if resp == nil || (resp.StatusCode >= 200 && resp.StatusCode < 300) {
+		return ""
+	}
+
+	sample, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseSample))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(sample), resp.Body), resp.Body}
+
+	return secret.Redact(string(sample))
+}
+
+func decodeResponse(resp *http.Response, v any) (string, error) {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %s", secret.Redact(err.Error()))
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return secret.Redact(string(body[:min(len(body), maxResponseSample)])), fmt.Errorf("invalid response body: %w", err)
+	}
+	return "", nil
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/brianvoe/gofakeit/v6"
 	"load-test/internal/loadtest/client"
 	"load-test/internal/models"
+	"load-test/internal/secret"
 )
 
 type InteractionRequest struct {
@@ -25,18 +26,19 @@ func RecordView(httpClient *client.HTTPClient, movieID string, metricsChan chan<
 	success := err == nil && resp != nil && (resp.StatusCode == 200 || resp.StatusCode == 201)
 	errorMsg := ""
 	if err != nil {
-		errorMsg = err.Error()
+		errorMsg = secret.Redact(err.Error())
 	}
 
 	metricsChan <- models.Metric{
-		Timestamp:  time.Now(),
-		Scenario:   "interactions",
-		Endpoint:   "/interactions",
-		Method:     "POST",
-		StatusCode: getStatusCode(resp),
-		Duration:   duration,
-		Success:    success,
-		Error:      errorMsg,
+		Timestamp:    time.Now(),
+		Scenario:     "interactions",
+		Endpoint:     "/interactions",
+		Method:       "POST",
+		StatusCode:   getStatusCode(resp),
+		Duration:     duration,
+		Success:      success,
+		Error:        errorMsg,
+		ResponseBody: getResponseSample(resp),
 	}
 
 	if resp != nil {
@@ -57,18 +59,19 @@ func LikeMovie(httpClient *client.HTTPClient, movieID string, metricsChan chan<-
 	success := err == nil && resp != nil && (resp.StatusCode == 200 || resp.StatusCode == 201)
 	errorMsg := ""
 	if err != nil {
-		errorMsg = err.Error()
+		errorMsg = secret.Redact(err.Error())
 	}
 
 	metricsChan <- models.Metric{
-		Timestamp:  time.Now(),
-		Scenario:   "interactions",
-		Endpoint:   "/interactions",
-		Method:     "POST",
-		StatusCode: getStatusCode(resp),
-		Duration:   duration,
-		Success:    success,
-		Error:      errorMsg,
+		Timestamp:    time.Now(),
+		Scenario:     "interactions",
+		Endpoint:     "/interactions",
+		Method:       "POST",
+		StatusCode:   getStatusCode(resp),
+		Duration:     duration,
+		Success:      success,
+		Error:        errorMsg,
+		ResponseBody: getResponseSample(resp),
 	}
 
 	if resp != nil {
@@ -91,18 +94,19 @@ func RateMovie(httpClient *client.HTTPClient, movieID string, metricsChan chan<-
 	success := err == nil && resp != nil && (resp.StatusCode == 200 || resp.StatusCode == 201)
 	errorMsg := ""
 	if err != nil {
-		errorMsg = err.Error()
+		errorMsg = secret.Redact(err.Error())
 	}
 
 	metricsChan <- models.Metric{
-		Timestamp:  time.Now(),
-		Scenario:   "interactions",
-		Endpoint:   "/interactions",
-		Method:     "POST",
-		StatusCode: getStatusCode(resp),
-		Duration:   duration,
-		Success:    success,
-		Error:      errorMsg,
+		Timestamp:    time.Now(),
+		Scenario:     "interactions",
+		Endpoint:     "/interactions",
+		Method:       "POST",
+		StatusCode:   getStatusCode(resp),
+		Duration:     duration,
+		Success:      success,
+		Error:        errorMsg,
+		ResponseBody: getResponseSample(resp),
 	}
 
 	if resp != nil {
@@ -123,18 +127,19 @@ func AddToWatchlist(httpClient *client.HTTPClient, movieID string, metricsChan c
 	success := err == nil && resp != nil && (resp.StatusCode == 200 || resp.StatusCode == 201)
 	errorMsg := ""
 	if err != nil {
-		errorMsg = err.Error()
+		errorMsg = secret.Redact(err.Error())
 	}
 
 	metricsChan <- models.Metric{
-		Timestamp:  time.Now(),
-		Scenario:   "interactions",
-		Endpoint:   "/interactions",
-		Method:     "POST",
-		StatusCode: getStatusCode(resp),
-		Duration:   duration,
-		Success:    success,
-		Error:      errorMsg,
+		Timestamp:    time.Now(),
+		Scenario:     "interactions",
+		Endpoint:     "/interactions",
+		Method:       "POST",
+		StatusCode:   getStatusCode(resp),
+		Duration:     duration,
+		Success:      success,
+		Error:        errorMsg,
+		ResponseBody: getResponseSample(resp),
 	}
 
 	if resp != nil {
@@ -155,18 +160,19 @@ func PurchaseMovie(httpClient *client.HTTPClient, movieID string, metricsChan ch
 	success := err == nil && resp != nil && (resp.StatusCode == 200 || resp.StatusCode == 201)
 	errorMsg := ""
 	if err != nil {
-		errorMsg = err.Error()
+		errorMsg = secret.Redact(err.Error())
 	}
 
 	metricsChan <- models.Metric{
-		Timestamp:  time.Now(),
-		Scenario:   "interactions",
-		Endpoint:   "/interactions",
-		Method:     "POST",
-		StatusCode: getStatusCode(resp),
-		Duration:   duration,
-		Success:    success,
-		Error:      errorMsg,
+		Timestamp:    time.Now(),
+		Scenario:     "interactions",
+		Endpoint:     "/interactions",
+		Method:       "POST",
+		StatusCode:   getStatusCode(resp),
+		Duration:     duration,
+		Success:      success,
+		Error:        errorMsg,
+		ResponseBody: getResponseSample(resp),
 	}
 
 	if resp != nil {
@@ -182,18 +188,19 @@ func GetUserInteractions(httpClient *client.HTTPClient, metricsChan chan<- model
 	success := err == nil && resp != nil && resp.StatusCode == 200
 	errorMsg := ""
 	if err != nil {
-		errorMsg = err.Error()
+		errorMsg = secret.Redact(err.Error())
 	}
 
 	metricsChan <- models.Metric{
-		Timestamp:  time.Now(),
-		Scenario:   "interactions",
-		Endpoint:   "/interactions",
-		Method:     "GET",
-		StatusCode: getStatusCode(resp),
-		Duration:   duration,
-		Success:    success,
-		Error:      errorMsg,
+		Timestamp:    time.Now(),
+		Scenario:     "interactions",
+		Endpoint:     "/interactions",
+		Method:       "GET",
+		StatusCode:   getStatusCode(resp),
+		Duration:     duration,
+		Success:      success,
+		Error:        errorMsg,
+		ResponseBody: getResponseSample(resp),
 	}
 
 	if resp != nil {
@@ -209,18 +216,19 @@ func GetWatchlist(httpClient *client.HTTPClient, metricsChan chan<- models.Metri
 	success := err == nil && resp != nil && resp.StatusCode == 200
 	errorMsg := ""
 	if err != nil {
-		errorMsg = err.Error()
+		errorMsg = secret.Redact(err.Error())
 	}
 
 	metricsChan <- models.Metric{
-		Timestamp:  time.Now(),
-		Scenario:   "interactions",
-		Endpoint:   "/watchlist",
-		Method:     "GET",
-		StatusCode: getStatusCode(resp),
-		Duration:   duration,
-		Success:    success,
-		Error:      errorMsg,
+		Timestamp:    time.Now(),
+		Scenario:     "interactions",
+		Endpoint:     "/watchlist",
+		Method:       "GET",
+		StatusCode:   getStatusCode(resp),
+		Duration:     duration,
+		Success:      success,
+		Error:        errorMsg,
+		ResponseBody: getResponseSample(resp),
 	}
 
 	if resp != nil {
@@ -236,18 +244,19 @@ func GetPurchases(httpClient *client.HTTPClient, metricsChan chan<- models.Metri
 	success := err == nil && resp != nil && resp.StatusCode == 200
 	errorMsg := ""
 	if err != nil {
-		errorMsg = err.Error()
+		errorMsg = secret.Redact(err.Error())
 	}
 
 	metricsChan <- models.Metric{
-		Timestamp:  time.Now(),
-		Scenario:   "interactions",
-		Endpoint:   "/purchases",
-		Method:     "GET",
-		StatusCode: getStatusCode(resp),
-		Duration:   duration,
-		Success:    success,
-		Error:      errorMsg,
+		Timestamp:    time.Now(),
+		Scenario:     "interactions",
+		Endpoint:     "/purchases",
+		Method:       "GET",
+		StatusCode:   getStatusCode(resp),
+		Duration:     duration,
+		Success:      success,
+		Error:        errorMsg,
+		ResponseBody: getResponseSample(resp),
 	}
 
 	if resp != nil {
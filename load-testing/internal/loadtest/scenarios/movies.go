@@ -1,14 +1,13 @@
 package scenarios
 
 import (
-	"encoding/json"
 	"fmt"
-	"io"
 	"time"
 
 	"github.com/brianvoe/gofakeit/v6"
 	"load-test/internal/loadtest/client"
 	"load-test/internal/models"
+	"load-test/internal/secret"
 )
 
 type MoviesResponse struct {
@@ -44,35 +43,36 @@ func GetAllMovies(httpClient *client.HTTPClient, metricsChan chan<- models.Metri
 	success := err == nil && resp != nil && resp.StatusCode == 200
 	errorMsg := ""
 	if err != nil {
-		errorMsg = err.Error()
+		errorMsg = secret.Redact(err.Error())
+	}
+	sample := getResponseSample(resp)
+
+	var moviesResp MoviesResponse
+	if success {
+		if sample, err = decodeResponse(resp, &moviesResp); err != nil {
+			success, errorMsg = false, err.Error()
+		}
+	}
+	if resp != nil {
+		resp.Body.Close()
 	}
 
 	metricsChan <- models.Metric{
-		Timestamp:  time.Now(),
-		Scenario:   "movies",
-		Endpoint:   "/movies",
-		Method:     "GET",
-		StatusCode: getStatusCode(resp),
-		Duration:   duration,
-		Success:    success,
-		Error:      errorMsg,
+		Timestamp:    time.Now(),
+		Scenario:     "movies",
+		Endpoint:     "/movies",
+		Method:       "GET",
+		StatusCode:   getStatusCode(resp),
+		Duration:     duration,
+		Success:      success,
+		Error:        errorMsg,
+		ResponseBody: sample,
 	}
 
 	if !success {
-		if resp != nil {
-			resp.Body.Close()
-		}
 		return nil, fmt.Errorf("get movies failed")
 	}
 
-	defer resp.Body.Close()
-	body, _ := io.ReadAll(resp.Body)
-
-	var moviesResp MoviesResponse
-	if err := json.Unmarshal(body, &moviesResp); err != nil {
-		return nil, err
-	}
-
 	movieIDs := make([]string, len(moviesResp.Movies))
 	for i, movie := range moviesResp.Movies {
 		movieIDs[i] = movie.ID
@@ -93,18 +93,19 @@ func GetMovieByID(httpClient *client.HTTPClient, movieID string, metricsChan cha
 	success := err == nil && resp != nil && resp.StatusCode == 200
 	errorMsg := ""
 	if err != nil {
-		errorMsg = err.Error()
+		errorMsg = secret.Redact(err.Error())
 	}
 
 	metricsChan <- models.Metric{
-		Timestamp:  time.Now(),
-		Scenario:   "movies",
-		Endpoint:   "/movies/:id",
-		Method:     "GET",
-		StatusCode: getStatusCode(resp),
-		Duration:   duration,
-		Success:    success,
-		Error:      errorMsg,
+		Timestamp:    time.Now(),
+		Scenario:     "movies",
+		Endpoint:     "/movies/:id",
+		Method:       "GET",
+		StatusCode:   getStatusCode(resp),
+		Duration:     duration,
+		Success:      success,
+		Error:        errorMsg,
+		ResponseBody: getResponseSample(resp),
 	}
 
 	if resp != nil {
@@ -126,18 +127,19 @@ func SearchMovies(httpClient *client.HTTPClient, metricsChan chan<- models.Metri
 	success := err == nil && resp != nil && resp.StatusCode == 200
 	errorMsg := ""
 	if err != nil {
-		errorMsg = err.Error()
+		errorMsg = secret.Redact(err.Error())
 	}
 
 	metricsChan <- models.Metric{
-		Timestamp:  time.Now(),
-		Scenario:   "movies",
-		Endpoint:   "/movies/search",
-		Method:     "GET",
-		StatusCode: getStatusCode(resp),
-		Duration:   duration,
-		Success:    success,
-		Error:      errorMsg,
+		Timestamp:    time.Now(),
+		Scenario:     "movies",
+		Endpoint:     "/movies/search",
+		Method:       "GET",
+		StatusCode:   getStatusCode(resp),
+		Duration:     duration,
+		Success:      success,
+		Error:        errorMsg,
+		ResponseBody: getResponseSample(resp),
 	}
 
 	if resp != nil {
@@ -159,18 +161,19 @@ func GetMoviesByGenre(httpClient *client.HTTPClient, metricsChan chan<- models.M
 	success := err == nil && resp != nil && resp.StatusCode == 200
 	errorMsg := ""
 	if err != nil {
-		errorMsg = err.Error()
+		errorMsg = secret.Redact(err.Error())
 	}
 
 	metricsChan <- models.Metric{
-		Timestamp:  time.Now(),
-		Scenario:   "movies",
-		Endpoint:   "/movies/genre/:genre",
-		Method:     "GET",
-		StatusCode: getStatusCode(resp),
-		Duration:   duration,
-		Success:    success,
-		Error:      errorMsg,
+		Timestamp:    time.Now(),
+		Scenario:     "movies",
+		Endpoint:     "/movies/genre/:genre",
+		Method:       "GET",
+		StatusCode:   getStatusCode(resp),
+		Duration:     duration,
+		Success:      success,
+		Error:        errorMsg,
+		ResponseBody: getResponseSample(resp),
 	}
 
 	if resp != nil {
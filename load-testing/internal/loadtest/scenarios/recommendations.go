@@ -7,6 +7,7 @@ import (
 	"github.com/brianvoe/gofakeit/v6"
 	"load-test/internal/loadtest/client"
 	"load-test/internal/models"
+	"load-test/internal/secret"
 )
 
 type RecommendationsResponse struct {
@@ -26,18 +27,19 @@ func GetRecommendations(httpClient *client.HTTPClient, strategy string, metricsC
 	success := err == nil && resp != nil && resp.StatusCode == 200
 	errorMsg := ""
 	if err != nil {
-		errorMsg = err.Error()
+		errorMsg = secret.Redact(err.Error())
 	}
 
 	metricsChan <- models.Metric{
-		Timestamp:  time.Now(),
-		Scenario:   "recommendations",
-		Endpoint:   "/recommendations",
-		Method:     "GET",
-		StatusCode: getStatusCode(resp),
-		Duration:   duration,
-		Success:    success,
-		Error:      errorMsg,
+		Timestamp:    time.Now(),
+		Scenario:     "recommendations",
+		Endpoint:     "/recommendations",
+		Method:       "GET",
+		StatusCode:   getStatusCode(resp),
+		Duration:     duration,
+		Success:      success,
+		Error:        errorMsg,
+		ResponseBody: getResponseSample(resp),
 	}
 
 	if resp != nil {
@@ -68,18 +70,19 @@ func GetSimilarMovies(httpClient *client.HTTPClient, movieID string, metricsChan
 	success := err == nil && resp != nil && resp.StatusCode == 200
 	errorMsg := ""
 	if err != nil {
-		errorMsg = err.Error()
+		errorMsg = secret.Redact(err.Error())
 	}
 
 	metricsChan <- models.Metric{
-		Timestamp:  time.Now(),
-		Scenario:   "recommendations",
-		Endpoint:   "/recommendations/similar/:id",
-		Method:     "GET",
-		StatusCode: getStatusCode(resp),
-		Duration:   duration,
-		Success:    success,
-		Error:      errorMsg,
+		Timestamp:    time.Now(),
+		Scenario:     "recommendations",
+		Endpoint:     "/recommendations/similar/:id",
+		Method:       "GET",
+		StatusCode:   getStatusCode(resp),
+		Duration:     duration,
+		Success:      success,
+		Error:        errorMsg,
+		ResponseBody: getResponseSample(resp),
 	}
 
 	if resp != nil {
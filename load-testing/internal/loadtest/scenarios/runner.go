@@ -106,3 +106,34 @@ func RunAllScenarios(httpClient *client.HTTPClient, email string, metricsChan ch
 		RunAuthScenario(httpClient, email, metricsChan)
 	}
 }
+
+func RunScenario(httpClient *client.HTTPClient, scenario string, email string, metricsChan chan<- models.Metric) {
+	switch scenario {
+	case "auth":
+		RunAuthScenario(httpClient, email, metricsChan)
+	case "movies":
+		RunMoviesScenario(httpClient, metricsChan)
+	case "recommendations":
+		RunRecommendationsScenario(httpClient, metricsChan)
+	case "interactions":
+		RunInteractionsScenario(httpClient, metricsChan)
+	case "all":
+		RunAllScenarios(httpClient, email, metricsChan)
+	}
+}
+
+func SetupUser(httpClient *client.HTTPClient, userID int, metricsChan chan<- models.Metric) (string, error) {
+	token, email, err := Register(httpClient, userID, metricsChan)
+	if err != nil {
+		return "", err
+	}
+
+	httpClient.SetToken(token)
+
+	movieIDs, _ := GetAllMovies(httpClient, metricsChan)
+	for i := 0; i < len(movieIDs) && i < 5; i++ {
+		RecordView(httpClient, movieIDs[i], metricsChan)
+	}
+
+	return email, nil
+}
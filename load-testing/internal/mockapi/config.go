@@ -0,0 +1,217 @@
+package mockapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"time"
+)
+
+const (
+	DistributionFixed     = "fixed"
+	DistributionUniform   = "uniform"
+	DistributionNormal    = "normal"
+	DistributionLogNormal = "lognormal"
+)
+
+type Config struct {
+	Default RouteConfig
+	Routes  map[string]RouteConfig
+}
+
+type RouteConfig struct {
+	Latency     Latency
+	ErrorRate   float64
+	ErrorStatus int
+}
+
+type Latency struct {
+	Distribution string
+	Median       time.Duration
+	Min          time.Duration
+	Max          time.Duration
+	StdDev       time.Duration
+	P99          time.Duration
+}
+
+type fileLatency struct {
+	Distribution string `json:"distribution"`
+	Median       string `json:"median"`
+	Min          string `json:"min"`
+	Max          string `json:"max"`
+	StdDev       string `json:"stddev"`
+	P99          string `json:"p99"`
+}
+
+type fileRoute struct {
+	Latency     *fileLatency `json:"latency"`
+	ErrorRate   *float64     `json:"errorRate"`
+	ErrorStatus int          `json:"errorStatus"`
+}
+
+type configFile struct {
+	Default *fileRoute           `json:"default"`
+	Routes  map[string]fileRoute `json:"routes"`
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Default: RouteConfig{
+			Latency: Latency{
+				Distribution: DistributionLogNormal,
+				Median:       20 * time.Millisecond,
+				P99:          120 * time.Millisecond,
+			},
+			ErrorStatus: 500,
+		},
+		Routes: map[string]RouteConfig{},
+	}
+}
+
+func LoadConfig(path string, cfg *Config, override func(*RouteConfig)) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read mock config: %w", err)
+	}
+
+	var file configFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse mock config %s: %w", path, err)
+	}
+
+	if file.Default != nil {
+		if err := file.Default.apply(&cfg.Default); err != nil {
+			return fmt.Errorf("mock config %s: default: %w", path, err)
+		}
+	}
+	if override != nil {
+		override(&cfg.Default)
+	}
+
+	for route, fileRoute := range file.Routes {
+		if !knownRoute(route) {
+			return fmt.Errorf("mock config %s: unknown route %q", path, route)
+		}
+		routeCfg := cfg.Default
+		if err := fileRoute.apply(&routeCfg); err != nil {
+			return fmt.Errorf("mock config %s: route %s: %w", path, route, err)
+		}
+		cfg.Routes[route] = routeCfg
+	}
+
+	return nil
+}
+
+func (c Config) For(route string) RouteConfig {
+	if routeCfg, exists := c.Routes[route]; exists {
+		return routeCfg
+	}
+	return c.Default
+}
+
+func (r RouteConfig) Validate() error {
+	if r.ErrorRate < 0 || r.ErrorRate > 100 {
+		return fmt.Errorf("error rate must be in [0, 100], got %g", r.ErrorRate)
+	}
+	if r.ErrorStatus < 400 || r.ErrorStatus > 599 {
+		return fmt.Errorf("error status must be a 4xx or 5xx code, got %d", r.ErrorStatus)
+	}
+	return r.Latency.Validate()
+}
+
+func (f fileRoute) apply(route *RouteConfig) error {
+	if f.Latency != nil {
+		latency, err := f.Latency.toLatency()
+		if err != nil {
+			return err
+		}
+		route.Latency = latency
+	}
+	if f.ErrorRate != nil {
+		if *f.ErrorRate < 0 || *f.ErrorRate > 100 {
+			return fmt.Errorf("errorRate must be in [0, 100], got %g", *f.ErrorRate)
+		}
+		route.ErrorRate = *f.ErrorRate
+	}
+	if f.ErrorStatus != 0 {
+		if f.ErrorStatus < 400 || f.ErrorStatus > 599 {
+			return fmt.Errorf("errorStatus must be a 4xx or 5xx code, got %d", f.ErrorStatus)
+		}
+		route.ErrorStatus = f.ErrorStatus
+	}
+	return nil
+}
+
+func (f fileLatency) toLatency() (Latency, error) {
+	latency := Latency{Distribution: f.Distribution}
+
+	fields := []struct {
+		name  string
+		value string
+		dst   *time.Duration
+	}{
+		{"median", f.Median, &latency.Median},
+		{"min", f.Min, &latency.Min},
+		{"max", f.Max, &latency.Max},
+		{"stddev", f.StdDev, &latency.StdDev},
+		{"p99", f.P99, &latency.P99},
+	}
+	for _, field := range fields {
+		if field.value == "" {
+			continue
+		}
+		d, err := time.ParseDuration(field.value)
+		if err != nil {
+			return latency, fmt.Errorf("invalid latency %s %q: %w", field.name, field.value, err)
+		}
+		*field.dst = d
+	}
+
+	return latency, latency.Validate()
+}
+
+func (l Latency) Validate() error {
+	switch l.Distribution {
+	case DistributionFixed:
+	case DistributionUniform:
+		if l.Max < l.Min {
+			return fmt.Errorf("uniform latency max %s is below min %s", l.Max, l.Min)
+		}
+	case DistributionNormal:
+		if l.Median <= 0 {
+			return fmt.Errorf("normal latency needs a positive median")
+		}
+	case DistributionLogNormal:
+		if l.Median <= 0 || l.P99 < l.Median {
+			return fmt.Errorf("lognormal latency needs a positive median and p99 >= median")
+		}
+	default:
+		return fmt.Errorf("unknown latency distribution %q (expected fixed|uniform|normal|lognormal)", l.Distribution)
+	}
+	return nil
+}
+
+func (l Latency) Sample(rng *rand.Rand) time.Duration {
+	var d float64
+	switch l.Distribution {
+	case DistributionUniform:
+		d = float64(l.Min) + rng.Float64()*float64(l.Max-l.Min)
+	case DistributionNormal:
+		d = float64(l.Median) + rng.NormFloat64()*float64(l.StdDev)
+	case DistributionLogNormal:
+		sigma := math.Log(float64(l.P99)/float64(l.Median)) / 2.326
+		d = float64(l.Median) * math.Exp(rng.NormFloat64()*sigma)
+	default:
+		d = float64(l.Median)
+	}
+
+	if l.Max > 0 && d > float64(l.Max) {
+		d = float64(l.Max)
+	}
+	if d < 0 {
+		d = 0
+	}
+	return time.Duration(d)
+}
@@ -0,0 +1,112 @@
+package mockapi
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestLatencyValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		latency Latency
+		wantErr bool
+	}{
+		{"fixed", Latency{Distribution: DistributionFixed, Median: 10 * time.Millisecond}, false},
+		{"uniform", Latency{Distribution: DistributionUniform, Min: time.Millisecond, Max: 5 * time.Millisecond}, false},
+		{"uniform with max below min", Latency{Distribution: DistributionUniform, Min: 5 * time.Millisecond, Max: time.Millisecond}, true},
+		{"normal", Latency{Distribution: DistributionNormal, Median: 10 * time.Millisecond, StdDev: time.Millisecond}, false},
+		{"normal without median", Latency{Distribution: DistributionNormal}, true},
+		{"lognormal", Latency{Distribution: DistributionLogNormal, Median: 10 * time.Millisecond, P99: 50 * time.Millisecond}, false},
+		{"lognormal with p99 below median", Latency{Distribution: DistributionLogNormal, Median: 10 * time.Millisecond, P99: 5 * time.Millisecond}, true},
+		{"unknown distribution", Latency{Distribution: "pareto"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.latency.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLatencySample(t *testing.T) {
+	tests := []struct {
+		name       string
+		latency    Latency
+		wantMin    time.Duration
+		wantMax    time.Duration
+		wantMedian time.Duration
+		wantP99    time.Duration
+	}{
+		{
+			name:       "fixed",
+			latency:    Latency{Distribution: DistributionFixed, Median: 20 * time.Millisecond},
+			wantMin:    20 * time.Millisecond,
+			wantMax:    20 * time.Millisecond,
+			wantMedian: 20 * time.Millisecond,
+		},
+		{
+			name:       "uniform",
+			latency:    Latency{Distribution: DistributionUniform, Min: 10 * time.Millisecond, Max: 30 * time.Millisecond},
+			wantMin:    10 * time.Millisecond,
+			wantMax:    30 * time.Millisecond,
+			wantMedian: 20 * time.Millisecond,
+		},
+		{
+			name:       "normal never goes negative",
+			latency:    Latency{Distribution: DistributionNormal, Median: 5 * time.Millisecond, StdDev: 10 * time.Millisecond},
+			wantMin:    0,
+			wantMedian: 5 * time.Millisecond,
+		},
+		{
+			name:       "lognormal hits its median and p99",
+			latency:    Latency{Distribution: DistributionLogNormal, Median: 20 * time.Millisecond, P99: 200 * time.Millisecond},
+			wantMin:    0,
+			wantMedian: 20 * time.Millisecond,
+			wantP99:    200 * time.Millisecond,
+		},
+		{
+			name:       "max caps the tail",
+			latency:    Latency{Distribution: DistributionLogNormal, Median: 20 * time.Millisecond, P99: 200 * time.Millisecond, Max: 50 * time.Millisecond},
+			wantMin:    0,
+			wantMax:    50 * time.Millisecond,
+			wantMedian: 20 * time.Millisecond,
+			wantP99:    50 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1))
+			samples := make([]time.Duration, 20000)
+			for i := range samples {
+				samples[i] = tt.latency.Sample(rng)
+			}
+			sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+
+			if samples[0] < tt.wantMin {
+				t.Errorf("min sample %s is below %s", samples[0], tt.wantMin)
+			}
+			if tt.wantMax > 0 && samples[len(samples)-1] > tt.wantMax {
+				t.Errorf("max sample %s is above %s", samples[len(samples)-1], tt.wantMax)
+			}
+			if got := samples[len(samples)/2]; !near(got, tt.wantMedian) {
+				t.Errorf("median = %s, want about %s", got, tt.wantMedian)
+			}
+			if got := samples[len(samples)*99/100]; tt.wantP99 > 0 && !near(got, tt.wantP99) {
+				t.Errorf("p99 = %s, want about %s", got, tt.wantP99)
+			}
+		})
+	}
+}
+
+func near(got, want time.Duration) bool {
+	diff := got - want
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= want/10
+}
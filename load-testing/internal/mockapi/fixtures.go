@@ -0,0 +1,109 @@
+package mockapi
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"load-test/internal/generator"
+	"load-test/internal/models"
+)
+
+func (s *Store) LoadFixtures(dir string) (map[string]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	credentials, err := generator.ReadCredentials(dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	err = readFixture(dir, "movies", func(raw bson.Raw) error {
+		var movie models.Movie
+		if err := bson.Unmarshal(raw, &movie); err != nil {
+			return err
+		}
+		s.addMovie(movie)
+		counts["movies"]++
+		return nil
+	})
+	if err != nil {
+		return counts, err
+	}
+
+	err = readFixture(dir, "users", func(raw bson.Raw) error {
+		var u models.User
+		if err := bson.Unmarshal(raw, &u); err != nil {
+			return err
+		}
+		user := &User{
+			ID:          u.ID.Hex(),
+			Email:       strings.ToLower(u.Email),
+			Username:    u.Username,
+			FirstName:   u.FirstName,
+			LastName:    u.LastName,
+			Preferences: Preferences{FavoriteGenres: u.Preferences.FavoriteGenres, DislikedGenres: u.Preferences.DislikedGenres},
+			CreatedAt:   u.CreatedAt,
+			password:    credentials[strings.ToLower(u.Email)],
+		}
+		s.users[user.Email] = user
+		s.usersByID[user.ID] = user
+		counts["users"]++
+		return nil
+	})
+	if err != nil {
+		return counts, err
+	}
+
+	err = readFixture(dir, "interactions", func(raw bson.Raw) error {
+		var i models.Interaction
+		if err := bson.Unmarshal(raw, &i); err != nil {
+			return err
+		}
+		userID := i.UserID.Hex()
+		s.interactions[userID] = append(s.interactions[userID], Interaction{
+			ID:        i.ID.Hex(),
+			UserID:    userID,
+			MovieID:   i.MovieID.Hex(),
+			Type:      i.Type,
+			Rating:    i.Rating,
+			CreatedAt: i.Timestamp,
+		})
+		counts["interactions"]++
+		return nil
+	})
+	for _, history := range s.interactions {
+		sort.SliceStable(history, func(a, b int) bool {
+			return history[a].CreatedAt.Before(history[b].CreatedAt)
+		})
+	}
+	return counts, err
+}
+
+func readFixture(dir, label string, handle func(bson.Raw) error) error {
+	reader, err := generator.OpenFixture(dir, label)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	for {
+		raw, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := handle(raw); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,370 @@
+package mockapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type handlerFunc func(w http.ResponseWriter, r *http.Request, params map[string]string, user *User)
+
+type route struct {
+	method  string
+	pattern string
+	auth    bool
+	handle  handlerFunc
+}
+
+type RouteStats struct {
+	Route    string
+	Requests int
+	Injected int
+}
+
+type Server struct {
+	store    *Store
+	config   Config
+	basePath string
+	routes   []route
+
+	rngMu sync.Mutex
+	rng   *rand.Rand
+
+	statsMu sync.Mutex
+	stats   map[string]*RouteStats
+}
+
+var routePatterns = []string{
+	"/auth/register", "/auth/login", "/auth/me",
+	"/movies", "/movies/search", "/movies/genre/:genre", "/movies/:id",
+	"/interactions", "/watchlist", "/purchases",
+	"/recommendations", "/recommendations/similar/:id",
+}
+
+func knownRoute(pattern string) bool {
+	for _, known := range routePatterns {
+		if known == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+func NewServer(store *Store, config Config, basePath string, seed int64) *Server {
+	s := &Server{
+		store:    store,
+		config:   config,
+		basePath: strings.TrimSuffix(basePath, "/"),
+		rng:      rand.New(rand.NewSource(seed)),
+		stats:    make(map[string]*RouteStats),
+	}
+
+	s.routes = []route{
+		{http.MethodPost, "/auth/register", false, s.register},
+		{http.MethodPost, "/auth/login", false, s.login},
+		{http.MethodGet, "/auth/me", true, s.me},
+		{http.MethodGet, "/movies", false, s.listMovies},
+		{http.MethodGet, "/movies/search", false, s.searchMovies},
+		{http.MethodGet, "/movies/genre/:genre", false, s.moviesByGenre},
+		{http.MethodGet, "/movies/:id", false, s.getMovie},
+		{http.MethodPost, "/interactions", true, s.recordInteraction},
+		{http.MethodGet, "/interactions", true, s.listInteractions},
+		{http.MethodGet, "/watchlist", true, s.listByType("watchlist", "watchlist")},
+		{http.MethodGet, "/purchases", true, s.listByType("purchase", "purchases")},
+		{http.MethodGet, "/recommendations", true, s.recommendations},
+		{http.MethodGet, "/recommendations/similar/:id", false, s.similar},
+	}
+
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/health" {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"status": "ok", "timestamp": time.Now().UTC().Format(time.RFC3339)})
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, s.basePath)
+	if path == r.URL.Path && s.basePath != "" {
+		s.notFound(w, r)
+		return
+	}
+
+	for _, rt := range s.routes {
+		if rt.method != r.Method {
+			continue
+		}
+		params, ok := matchPattern(rt.pattern, path)
+		if !ok {
+			continue
+		}
+		s.serveRoute(w, r, rt, params)
+		return
+	}
+
+	s.notFound(w, r)
+}
+
+func (s *Server) Stats() []RouteStats {
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+
+	list := make([]RouteStats, 0, len(s.stats))
+	for _, stats := range s.stats {
+		list = append(list, *stats)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Requests > list[j].Requests })
+	return list
+}
+
+func (s *Server) serveRoute(w http.ResponseWriter, r *http.Request, rt route, params map[string]string) {
+	routeCfg := s.config.For(rt.pattern)
+
+	s.rngMu.Lock()
+	delay := routeCfg.Latency.Sample(s.rng)
+	inject := s.rng.Float64()*100 < routeCfg.ErrorRate
+	s.rngMu.Unlock()
+
+	s.record(r.Method+" "+rt.pattern, inject)
+
+	select {
+	case <-time.After(delay):
+	case <-r.Context().Done():
+		return
+	}
+
+	if inject {
+		writeError(w, routeCfg.ErrorStatus, "Injected failure")
+		return
+	}
+
+	var user *User
+	if rt.auth {
+		authHeader := r.Header.Get("Authorization")
+		if !strings.HasPrefix(authHeader, "Bearer ") {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "No token provided"})
+			return
+		}
+		var err error
+		if user, err = s.store.Authenticate(strings.TrimPrefix(authHeader, "Bearer ")); err != nil {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": err.Error()})
+			return
+		}
+	}
+
+	rt.handle(w, r, params, user)
+}
+
+func (s *Server) record(route string, injected bool) {
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+
+	stats, exists := s.stats[route]
+	if !exists {
+		stats = &RouteStats{Route: route}
+		s.stats[route] = stats
+	}
+	stats.Requests++
+	if injected {
+		stats.Injected++
+	}
+}
+
+func (s *Server) notFound(w http.ResponseWriter, r *http.Request) {
+	writeError(w, http.StatusNotFound, fmt.Sprintf("Route %s:%s not found", r.Method, r.URL.Path))
+}
+
+func (s *Server) register(w http.ResponseWriter, r *http.Request, _ map[string]string, _ *User) {
+	var req struct {
+		Email     string `json:"email"`
+		Password  string `json:"password"`
+		Username  string `json:"username"`
+		FirstName string `json:"firstName"`
+		LastName  string `json:"lastName"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	switch {
+	case !strings.Contains(req.Email, "@"):
+		writeError(w, http.StatusBadRequest, "Invalid email")
+		return
+	case len(req.Password) < 6:
+		writeError(w, http.StatusBadRequest, "Password must contain at least 6 character(s)")
+		return
+	case len(req.Username) < 3 || len(req.Username) > 30:
+		writeError(w, http.StatusBadRequest, "Username must be between 3 and 30 characters")
+		return
+	}
+
+	user, token, err := s.store.Register(req.Email, req.Password, req.Username, req.FirstName, req.LastName)
+	if err != nil {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]interface{}{"user": user, "token": token})
+}
+
+func (s *Server) login(w http.ResponseWriter, r *http.Request, _ map[string]string, _ *User) {
+	var req struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	user, token, err := s.store.Login(req.Email, req.Password)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"user": user, "token": token})
+}
+
+func (s *Server) me(w http.ResponseWriter, _ *http.Request, _ map[string]string, user *User) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"user": user})
+}
+
+func (s *Server) listMovies(w http.ResponseWriter, r *http.Request, _ map[string]string, _ *User) {
+	limit := queryInt(r, "limit", defaultResultLimit)
+	skip := queryInt(r, "skip", 0)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"movies": s.store.Movies(skip, limit)})
+}
+
+func (s *Server) searchMovies(w http.ResponseWriter, r *http.Request, _ map[string]string, _ *User) {
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": `Query parameter "q" is required`})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"movies": s.store.Search(query, queryInt(r, "limit", defaultResultLimit))})
+}
+
+func (s *Server) moviesByGenre(w http.ResponseWriter, r *http.Request, params map[string]string, _ *User) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"movies": s.store.ByGenre(params["genre"], queryInt(r, "limit", defaultResultLimit))})
+}
+
+func (s *Server) getMovie(w http.ResponseWriter, _ *http.Request, params map[string]string, _ *User) {
+	movie, err := s.store.Movie(params["id"])
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"movie": movie})
+}
+
+func (s *Server) recordInteraction(w http.ResponseWriter, r *http.Request, _ map[string]string, user *User) {
+	var req struct {
+		MovieID string `json:"movieId"`
+		Type    string `json:"type"`
+		Rating  *int   `json:"rating"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	switch {
+	case req.MovieID == "":
+		writeError(w, http.StatusBadRequest, "movieId is required")
+		return
+	case !validInteractionType(req.Type):
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid interaction type %q", req.Type))
+		return
+	case req.Rating != nil && (*req.Rating < 1 || *req.Rating > 10):
+		writeError(w, http.StatusBadRequest, "Rating must be between 1 and 10")
+		return
+	}
+
+	if err := s.store.RecordInteraction(user.ID, req.MovieID, req.Type, req.Rating); err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, map[string]string{"message": "Interaction recorded successfully"})
+}
+
+func (s *Server) listInteractions(w http.ResponseWriter, r *http.Request, _ map[string]string, user *User) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"interactions": s.store.Interactions(user.ID, r.URL.Query().Get("type"))})
+}
+
+func (s *Server) listByType(interactionType, key string) handlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request, _ map[string]string, user *User) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{key: s.store.Interactions(user.ID, interactionType)})
+	}
+}
+
+func (s *Server) recommendations(w http.ResponseWriter, r *http.Request, _ map[string]string, user *User) {
+	strategy := r.URL.Query().Get("strategy")
+	if strategy == "" {
+		strategy = "hybrid"
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"recommendations": s.store.Recommendations(user, strategy, queryInt(r, "limit", 10))})
+}
+
+func (s *Server) similar(w http.ResponseWriter, r *http.Request, params map[string]string, _ *User) {
+	movies, err := s.store.Similar(params["id"], queryInt(r, "limit", 10))
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"similar": movies})
+}
+
+func matchPattern(pattern, path string) (map[string]string, bool) {
+	patternParts := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(patternParts) != len(pathParts) {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+	for i, part := range patternParts {
+		if strings.HasPrefix(part, ":") {
+			if pathParts[i] == "" {
+				return nil, false
+			}
+			params[part[1:]] = pathParts[i]
+			continue
+		}
+		if part != pathParts[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func queryInt(r *http.Request, key string, fallback int) int {
+	value, err := strconv.Atoi(r.URL.Query().Get(key))
+	if err != nil || value < 0 {
+		return fallback
+	}
+	return value
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"error": map[string]interface{}{
+			"message":    message,
+			"statusCode": status,
+		},
+	})
+}
@@ -0,0 +1,357 @@
+package mockapi
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v6"
+	"load-test/internal/generator"
+	"load-test/internal/models"
+)
+
+var (
+	errEmailTaken      = errors.New("User with this email already exists")
+	errBadCredentials  = errors.New("Invalid email or password")
+	errMovieNotFound   = errors.New("Movie not found")
+	errInvalidToken    = errors.New("Invalid or expired token")
+	interactionTypes   = []string{"view", "like", "dislike", "purchase", "rating", "watchlist"}
+	defaultResultLimit = 20
+)
+
+type Preferences struct {
+	FavoriteGenres []string `json:"favoriteGenres"`
+	DislikedGenres []string `json:"dislikedGenres"`
+}
+
+type User struct {
+	ID          string      `json:"_id"`
+	Email       string      `json:"email"`
+	Username    string      `json:"username"`
+	FirstName   string      `json:"firstName,omitempty"`
+	LastName    string      `json:"lastName,omitempty"`
+	Preferences Preferences `json:"preferences"`
+	CreatedAt   time.Time   `json:"createdAt"`
+	password    string
+}
+
+type Movie struct {
+	ID          string    `json:"_id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Genres      []string  `json:"genres"`
+	Director    string    `json:"director"`
+	Cast        []string  `json:"cast"`
+	ReleaseYear int       `json:"releaseYear"`
+	Duration    int       `json:"duration"`
+	Rating      float64   `json:"rating"`
+	PosterURL   string    `json:"posterUrl,omitempty"`
+	TrailerURL  string    `json:"trailerUrl,omitempty"`
+	Price       float64   `json:"price"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type Interaction struct {
+	ID        string    `json:"_id"`
+	UserID    string    `json:"userId"`
+	MovieID   string    `json:"movieId"`
+	Type      string    `json:"type"`
+	Rating    *int      `json:"rating,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type Store struct {
+	mu           sync.RWMutex
+	users        map[string]*User
+	usersByID    map[string]*User
+	tokens       map[string]string
+	movies       []*Movie
+	moviesByID   map[string]*Movie
+	interactions map[string][]Interaction
+}
+
+func NewStore(movieCount int, seed int64) *Store {
+	store := &Store{
+		users:        make(map[string]*User),
+		usersByID:    make(map[string]*User),
+		tokens:       make(map[string]string),
+		moviesByID:   make(map[string]*Movie),
+		interactions: make(map[string][]Interaction),
+	}
+
+	f := gofakeit.New(seed)
+	now := time.Now()
+	for i := 0; i < movieCount; i++ {
+		store.addMovie(generator.NewMovie(f, now))
+	}
+
+	return store
+}
+
+func (s *Store) addMovie(m models.Movie) {
+	movie := &Movie{
+		ID:          m.ID.Hex(),
+		Title:       m.Title,
+		Description: m.Description,
+		Genres:      m.Genres,
+		Director:    m.Director,
+		Cast:        m.Cast,
+		ReleaseYear: m.ReleaseYear,
+		Duration:    m.Duration,
+		Rating:      m.Rating,
+		PosterURL:   m.PosterURL,
+		TrailerURL:  m.TrailerURL,
+		Price:       m.Price,
+		CreatedAt:   m.CreatedAt,
+	}
+	s.movies = append(s.movies, movie)
+	s.moviesByID[movie.ID] = movie
+}
+
+func (s *Store) Register(email, password, username, firstName, lastName string) (*User, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	email = strings.ToLower(email)
+	if _, exists := s.users[email]; exists {
+		return nil, "", errEmailTaken
+	}
+
+	user := &User{
+		ID:          newID(),
+		Email:       email,
+		Username:    username,
+		FirstName:   firstName,
+		LastName:    lastName,
+		Preferences: Preferences{FavoriteGenres: []string{}, DislikedGenres: []string{}},
+		CreatedAt:   time.Now(),
+		password:    password,
+	}
+	s.users[email] = user
+	s.usersByID[user.ID] = user
+
+	token := s.issueToken(user.ID)
+	return user, token, nil
+}
+
+func (s *Store) Login(email, password string) (*User, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, exists := s.users[strings.ToLower(email)]
+	if !exists || user.password != password {
+		return nil, "", errBadCredentials
+	}
+
+	return user, s.issueToken(user.ID), nil
+}
+
+func (s *Store) Authenticate(token string) (*User, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	userID, exists := s.tokens[token]
+	if !exists {
+		return nil, errInvalidToken
+	}
+	return s.usersByID[userID], nil
+}
+
+func (s *Store) Movies(skip, limit int) []*Movie {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if skip >= len(s.movies) {
+		return []*Movie{}
+	}
+	end := skip + limit
+	if end > len(s.movies) {
+		end = len(s.movies)
+	}
+	return s.movies[skip:end]
+}
+
+func (s *Store) Movie(id string) (*Movie, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	movie, exists := s.moviesByID[id]
+	if !exists {
+		return nil, errMovieNotFound
+	}
+	return movie, nil
+}
+
+func (s *Store) Search(query string, limit int) []*Movie {
+	query = strings.ToLower(query)
+	return s.filter(limit, func(m *Movie) bool {
+		return strings.Contains(strings.ToLower(m.Title), query) || strings.Contains(strings.ToLower(m.Description), query)
+	})
+}
+
+func (s *Store) ByGenre(genre string, limit int) []*Movie {
+	return s.filter(limit, func(m *Movie) bool {
+		return hasGenre(m, genre)
+	})
+}
+
+func (s *Store) RecordInteraction(userID, movieID, interactionType string, rating *int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.moviesByID[movieID]; !exists {
+		return errMovieNotFound
+	}
+
+	s.interactions[userID] = append(s.interactions[userID], Interaction{
+		ID:        newID(),
+		UserID:    userID,
+		MovieID:   movieID,
+		Type:      interactionType,
+		Rating:    rating,
+		CreatedAt: time.Now(),
+	})
+	return nil
+}
+
+func (s *Store) Interactions(userID, interactionType string) []Interaction {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := []Interaction{}
+	history := s.interactions[userID]
+	for i := len(history) - 1; i >= 0; i-- {
+		if interactionType == "" || history[i].Type == interactionType {
+			result = append(result, history[i])
+		}
+	}
+	return result
+}
+
+func (s *Store) Recommendations(user *User, strategy string, limit int) []*Movie {
+	s.mu.RLock()
+	seen := make(map[string]bool)
+	genreWeight := make(map[string]int)
+	for _, genre := range user.Preferences.FavoriteGenres {
+		genreWeight[strings.ToLower(genre)] += 3
+	}
+	for _, interaction := range s.interactions[user.ID] {
+		seen[interaction.MovieID] = true
+		if movie, exists := s.moviesByID[interaction.MovieID]; exists && interaction.Type != "dislike" {
+			for _, genre := range movie.Genres {
+				genreWeight[strings.ToLower(genre)]++
+			}
+		}
+	}
+	s.mu.RUnlock()
+
+	score := func(m *Movie) float64 {
+		if strategy == "popular" {
+			return m.Rating
+		}
+		weight := 0
+		for _, genre := range m.Genres {
+			weight += genreWeight[strings.ToLower(genre)]
+		}
+		return float64(weight)*10 + m.Rating
+	}
+
+	return s.rank(limit, func(m *Movie) bool { return !seen[m.ID] }, score)
+}
+
+func (s *Store) Similar(movieID string, limit int) ([]*Movie, error) {
+	source, err := s.Movie(movieID)
+	if err != nil {
+		return nil, err
+	}
+
+	overlap := func(m *Movie) float64 {
+		shared := 0
+		for _, genre := range source.Genres {
+			if hasGenre(m, genre) {
+				shared++
+			}
+		}
+		return float64(shared)*10 + m.Rating
+	}
+
+	return s.rank(limit, func(m *Movie) bool { return m.ID != source.ID }, overlap), nil
+}
+
+func (s *Store) Counts() (int, int, int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	interactions := 0
+	for _, history := range s.interactions {
+		interactions += len(history)
+	}
+	return len(s.users), len(s.movies), interactions
+}
+
+func (s *Store) issueToken(userID string) string {
+	token := newID() + newID()
+	s.tokens[token] = userID
+	return token
+}
+
+func (s *Store) filter(limit int, keep func(*Movie) bool) []*Movie {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := []*Movie{}
+	for _, movie := range s.movies {
+		if len(result) == limit {
+			break
+		}
+		if keep(movie) {
+			result = append(result, movie)
+		}
+	}
+	return result
+}
+
+func (s *Store) rank(limit int, keep func(*Movie) bool, score func(*Movie) float64) []*Movie {
+	s.mu.RLock()
+	candidates := make([]*Movie, 0, len(s.movies))
+	for _, movie := range s.movies {
+		if keep(movie) {
+			candidates = append(candidates, movie)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.SliceStable(candidates, func(i, j int) bool { return score(candidates[i]) > score(candidates[j]) })
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	return candidates
+}
+
+func hasGenre(m *Movie, genre string) bool {
+	for _, g := range m.Genres {
+		if strings.EqualFold(g, genre) {
+			return true
+		}
+	}
+	return false
+}
+
+func validInteractionType(interactionType string) bool {
+	for _, t := range interactionTypes {
+		if t == interactionType {
+			return true
+		}
+	}
+	return false
+}
+
+func newID() string {
+	b := make([]byte, 12)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -16,6 +16,9 @@ type User struct {
 	Preferences Preferences        `bson:"preferences"`
 	CreatedAt   time.Time          `bson:"createdAt"`
 	UpdatedAt   time.Time          `bson:"updatedAt"`
+	Generation  string             `bson:"loadTestGeneration,omitempty"`
+
+	PlainPassword string `bson:"-"`
 }
 
 type Preferences struct {
@@ -38,26 +41,29 @@ type Movie struct {
 	Price       float64            `bson:"price"`
 	CreatedAt   time.Time          `bson:"createdAt"`
 	UpdatedAt   time.Time          `bson:"updatedAt"`
+	Generation  string             `bson:"loadTestGeneration,omitempty"`
 }
 
 type Interaction struct {
-	ID        primitive.ObjectID `bson:"_id,omitempty"`
-	UserID    primitive.ObjectID `bson:"userId"`
-	MovieID   primitive.ObjectID `bson:"movieId"`
-	Type      string             `bson:"type"`
-	Rating    *int               `bson:"rating,omitempty"`
-	Timestamp time.Time          `bson:"timestamp"`
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	UserID     primitive.ObjectID `bson:"userId"`
+	MovieID    primitive.ObjectID `bson:"movieId"`
+	Type       string             `bson:"type"`
+	Rating     *int               `bson:"rating,omitempty"`
+	Timestamp  time.Time          `bson:"timestamp"`
+	Generation string             `bson:"loadTestGeneration,omitempty"`
 }
 
 type Metric struct {
-	Timestamp  time.Time
-	Scenario   string
-	Endpoint   string
-	Method     string
-	StatusCode int
-	Duration   time.Duration
-	Success    bool
-	Error      string
+	Timestamp    time.Time
+	Scenario     string
+	Endpoint     string
+	Method       string
+	StatusCode   int
+	Duration     time.Duration
+	Success      bool
+	Error        string
+	ResponseBody string
 }
 
 type TestStats struct {
@@ -76,6 +82,9 @@ type TestStats struct {
 	ByScenario        map[string]ScenarioStats
 	ByEndpoint        map[string]EndpointStats
 	Errors            map[string]int
+	StatusCodes       map[int]int
+	ErrorCategories   map[string]int
+	ErrorSamples      map[string][]string
 }
 
 type ScenarioStats struct {
@@ -87,8 +96,205 @@ type ScenarioStats struct {
 }
 
 type EndpointStats struct {
-	Count       int
-	SuccessRate float64
-	AvgDuration float64
-	P95Duration float64
+	Count           int
+	SuccessRate     float64
+	AvgDuration     float64
+	P95Duration     float64
+	StatusCodes     map[int]int
+	ErrorCategories map[string]int
+}
+
+type SLOReport struct {
+	Overall    SLOResult
+	ByEndpoint map[string]SLOResult
+}
+
+type SLOResult struct {
+	Name             string
+	LatencyTargetMs  float64
+	SuccessObjective float64
+	WindowSeconds    float64
+	Total            int
+	Good             int
+	Satisfied        int
+	Tolerating       int
+	Frustrated       int
+	Apdex            float64
+	Rating           string
+	Compliance       float64
+	Met              bool
+	ErrorBudget      float64
+	BudgetConsumed   float64
+	BudgetRemaining  float64
+	MaxBurnRate      float64
+	BurnRate         []BurnRatePoint
+}
+
+type BurnRatePoint struct {
+	Timestamp time.Time
+	BurnRate  float64
+}
+
+type RunMetadata struct {
+	Mode      string
+	Scenario  string
+	Users     int
+	StartedAt time.Time
+	Duration  time.Duration
+	Capacity  *CapacityResult  `json:",omitempty"`
+	Soak      *SoakReport      `json:",omitempty"`
+	Workers   []WorkerSummary  `json:",omitempty"`
+	Aggregate *AggregateResult `json:",omitempty"`
+}
+
+type AggregateResult struct {
+	Stats TestStats
+	SLO   SLOReport
+}
+
+type WorkerSummary struct {
+	ID       string
+	Name     string
+	Requests int
+	Reports  int
+	Final    bool
+}
+
+type CapacityResult struct {
+	Strategy           string
+	MaxSustainableRate float64
+	KneeRate           float64
+	Steps              []CapacityStep
+}
+
+type CapacityStep struct {
+	TargetRate        float64
+	AchievedRate      float64
+	Throughput        float64
+	Requests          int
+	SuccessRate       float64
+	MedianDuration    float64
+	P95Duration       float64
+	P99Duration       float64
+	Apdex             float64
+	Compliance        float64
+	DroppedIterations int
+	Passed            bool
+	Reason            string
+	StartedAt         time.Time
+}
+
+type SoakReport struct {
+	WindowSeconds       float64
+	StepSeconds         float64
+	DriftThreshold      float64
+	ErrorDriftThreshold float64
+	Overall             SoakSeries
+	ByEndpoint          map[string]SoakSeries
+	Resources           []ResourceSample `json:",omitempty"`
+	ResourceTrends      []TrendResult    `json:",omitempty"`
+	Drifting            bool
+}
+
+type SoakSeries struct {
+	Name     string
+	Windows  []SoakWindow
+	Trends   []TrendResult
+	Drifting bool
+}
+
+type SoakWindow struct {
+	Start          time.Time
+	Requests       int
+	Throughput     float64
+	ErrorRate      float64
+	MedianDuration float64
+	P95Duration    float64
+}
+
+type TrendResult struct {
+	Metric        string
+	Samples       int
+	Baseline      float64
+	SlopePerHour  float64
+	ChangePerHour float64
+	RSquared      float64
+	TStat         float64
+	Significant   bool
+	Drifting      bool
+}
+
+type ResourceSample struct {
+	Timestamp       time.Time
+	HealthStatus    int
+	HealthLatencyMs float64
+	HealthError     string  `json:",omitempty"`
+	ResidentMB      float64 `json:",omitempty"`
+	VirtualMB       float64 `json:",omitempty"`
+	Connections     int     `json:",omitempty"`
+}
+
+type FaultLog struct {
+	Target    string
+	StartedAt time.Time
+	Phases    []FaultPhase
+}
+
+type FaultPhase struct {
+	Name     string
+	Start    time.Time
+	End      time.Time
+	Requests int
+	Injected []FaultCount
+}
+
+type FaultCount struct {
+	Type  string
+	Route string
+	Count int
+}
+
+type DatasetStats struct {
+	Database        string
+	Generation      string
+	Dataset         string
+	Source          string
+	AnalyzedAt      time.Time
+	Users           int64
+	Movies          int64
+	Interactions    int64
+	ColdStartUsers  int64
+	ColdStartMovies int64
+	UserMoviePairs  int64
+	Density         float64
+	Sparsity        float64
+	PerUser         CountDistribution
+	PerMovie        CountDistribution
+	Types           []NamedCount
+	Ratings         []NamedCount
+	Genres          []GenreCount
+	UnknownUsers    int64
+	UnknownMovies   int64
+}
+
+type CountDistribution struct {
+	Mean     float64
+	Median   int64
+	P90      int64
+	P99      int64
+	Max      int64
+	Gini     float64
+	TopShare float64
+	Buckets  []NamedCount
+}
+
+type NamedCount struct {
+	Name  string
+	Count int64
+}
+
+type GenreCount struct {
+	Genre        string
+	Movies       int64
+	Interactions int64
 }
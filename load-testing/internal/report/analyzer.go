@@ -2,14 +2,22 @@ package report
 
 import (
 	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
 	"time"
 
+	"load-test/internal/loadtest/metrics"
 	"load-test/internal/models"
 )
 
+var (
+	ErrNoMetrics     = errors.New("CSV file is empty or has only headers")
+	ErrAggregatedCSV = errors.New("CSV file holds merged per-series rows, not per-request metrics")
+)
+
 func LoadMetricsFromCSV(filename string) ([]models.Metric, error) {
 	file, err := os.Open(filename)
 	if err != nil {
@@ -18,13 +26,17 @@ func LoadMetricsFromCSV(filename string) ([]models.Metric, error) {
 	defer file.Close()
 
 	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
 	records, err := reader.ReadAll()
 	if err != nil {
 		return nil, err
 	}
 
+	if len(records) > 0 && len(records[0]) == len(metrics.AggregatedCSVHeader) && records[0][0] == metrics.AggregatedCSVHeader[0] {
+		return nil, ErrAggregatedCSV
+	}
 	if len(records) < 2 {
-		return nil, fmt.Errorf("CSV file is empty or has only headers")
+		return nil, ErrNoMetrics
 	}
 
 	metrics := make([]models.Metric, 0, len(records)-1)
@@ -45,11 +57,15 @@ func LoadMetricsFromCSV(filename string) ([]models.Metric, error) {
 			Endpoint:   record[2],
 			Method:     record[3],
 			StatusCode: statusCode,
-			Duration:   time.Duration(durationMs) * time.Millisecond,
+			Duration:   time.Duration(durationMs * float64(time.Millisecond)),
 			Success:    success,
 			Error:      record[7],
 		}
 
+		if len(record) > 8 {
+			metric.ResponseBody = record[8]
+		}
+
 		metrics = append(metrics, metric)
 	}
 
@@ -61,6 +77,8 @@ type TimeSeriesData struct {
 	RequestsPerSec  []float64
 	AvgResponseTime []float64
 	ErrorRate       []float64
+	Start           time.Time
+	BucketSize      time.Duration
 }
 
 func GenerateTimeSeries(metrics []models.Metric, bucketSize time.Duration) TimeSeriesData {
@@ -143,6 +161,8 @@ func GenerateTimeSeries(metrics []models.Metric, bucketSize time.Duration) TimeS
 		RequestsPerSec:  requestsPerSec,
 		AvgResponseTime: avgResponseTime,
 		ErrorRate:       errorRate,
+		Start:           time.Unix(minTime.Unix()/int64(bucketSize.Seconds())*int64(bucketSize.Seconds()), 0),
+		BucketSize:      bucketSize,
 	}
 }
 
@@ -152,3 +172,34 @@ type bucket struct {
 	durations []float64
 	errors    int
 }
+
+func LoadMetadata(filename string) (*models.RunMetadata, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var metadata models.RunMetadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse run metadata %s: %w", filename, err)
+	}
+
+	return &metadata, nil
+}
+
+func LoadFaultLog(filename string) (*models.FaultLog, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fault record: %w", err)
+	}
+
+	var faultLog models.FaultLog
+	if err := json.Unmarshal(data, &faultLog); err != nil {
+		return nil, fmt.Errorf("failed to parse fault record %s: %w", filename, err)
+	}
+
+	return &faultLog, nil
+}
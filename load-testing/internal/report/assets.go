@@ -0,0 +1,78 @@
+package report
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+)
+
+//go:generate sh assets/vendor/fetch.sh
+
+const (
+	chartJSCDN    = "https://cdn.jsdelivr.net/npm/chart.js@4.4.0/dist/chart.umd.min.js"
+	chartJSVendor = "assets/vendor/chart.umd.min.js"
+)
+
+type AssetMode string
+
+const (
+	AssetsInline   AssetMode = "inline"
+	AssetsExternal AssetMode = "external"
+)
+
+//go:embed assets/vendor
+var vendor embed.FS
+
+//go:embed assets/report.js
+var reportJS string
+
+//go:embed assets/report.css
+var reportCSS string
+
+type AssetData struct {
+	Inline     bool
+	ChartJS    template.JS
+	ChartJSURL string
+	ReportJS   template.JS
+	CSS        template.CSS
+}
+
+func ParseAssetMode(value string) (AssetMode, error) {
+	switch AssetMode(value) {
+	case AssetsInline, AssetsExternal:
+		return AssetMode(value), nil
+	default:
+		return "", fmt.Errorf("unknown assets mode %q (expected inline|external)", value)
+	}
+}
+
+func chartJSVendored() bool {
+	_, err := vendor.ReadFile(chartJSVendor)
+	return err == nil
+}
+
+func CheckAssets(mode AssetMode) error {
+	if mode == AssetsExternal || chartJSVendored() {
+		return nil
+	}
+	return fmt.Errorf("inline assets need the vendored Chart.js bundle (%s): run go generate ./internal/report, or use -assets external to load it from the CDN", chartJSVendor)
+}
+
+func loadAssets(mode AssetMode) (AssetData, error) {
+	assets := AssetData{
+		ChartJSURL: chartJSCDN,
+		ReportJS:   template.JS(reportJS),
+		CSS:        template.CSS(reportCSS),
+	}
+	if mode == AssetsExternal {
+		return assets, nil
+	}
+
+	chartJS, err := vendor.ReadFile(chartJSVendor)
+	if err != nil {
+		return AssetData{}, CheckAssets(mode)
+	}
+	assets.Inline = true
+	assets.ChartJS = template.JS(chartJS)
+	return assets, nil
+}
@@ -0,0 +1,91 @@
+    function drawHeatmap(canvas, heatmap, gridColor) {
+        const ctx = canvas.getContext('2d');
+        const ratio = window.devicePixelRatio || 1;
+        const width = canvas.clientWidth;
+        const height = canvas.clientHeight;
+        canvas.width = width * ratio;
+        canvas.height = height * ratio;
+        ctx.setTransform(ratio, 0, 0, ratio, 0, 0);
+        ctx.clearRect(0, 0, width, height);
+
+        const margin = { top: 10, right: 20, bottom: 40, left: 70 };
+        const plotWidth = width - margin.left - margin.right;
+        const plotHeight = height - margin.top - margin.bottom;
+        const rows = heatmap.LatencyLabels.length;
+        const cols = heatmap.TimeLabels.length;
+        if (rows === 0 || cols === 0) {
+            return;
+        }
+
+        const cellWidth = plotWidth / cols;
+        const cellHeight = plotHeight / rows;
+        const maxLog = Math.log(heatmap.MaxCount + 1);
+
+        for (let row = 0; row < rows; row++) {
+            for (let col = 0; col < cols; col++) {
+                const count = heatmap.Cells[row][col];
+                if (count === 0) {
+                    continue;
+                }
+                const intensity = Math.log(count + 1) / maxLog;
+                ctx.fillStyle = 'rgba(118, 75, 162, ' + (0.1 + 0.9 * intensity).toFixed(3) + ')';
+                ctx.fillRect(
+                    margin.left + col * cellWidth,
+                    margin.top + plotHeight - (row + 1) * cellHeight,
+                    Math.ceil(cellWidth),
+                    Math.ceil(cellHeight)
+                );
+            }
+        }
+
+        ctx.strokeStyle = gridColor;
+        ctx.strokeRect(margin.left, margin.top, plotWidth, plotHeight);
+
+        ctx.fillStyle = '#666';
+        ctx.font = '11px sans-serif';
+        ctx.textAlign = 'right';
+        ctx.textBaseline = 'middle';
+        const rowStep = Math.max(1, Math.ceil(rows / (plotHeight / 20)));
+        for (let row = 0; row < rows; row += rowStep) {
+            ctx.fillText(heatmap.LatencyLabels[row], margin.left - 6, margin.top + plotHeight - (row + 0.5) * cellHeight);
+        }
+
+        ctx.textAlign = 'center';
+        ctx.textBaseline = 'top';
+        const colStep = Math.max(1, Math.ceil(cols / (plotWidth / 70)));
+        for (let col = 0; col < cols; col += colStep) {
+            ctx.fillText(heatmap.TimeLabels[col], margin.left + (col + 0.5) * cellWidth, margin.top + plotHeight + 6);
+        }
+    }
+
+    function faultBandsPlugin(bands, fillColor, labelColor) {
+        return {
+            id: 'faultBands',
+            beforeDatasetsDraw(chart) {
+                if (!bands || bands.length === 0) {
+                    return;
+                }
+                const area = chart.chartArea;
+                const x = chart.scales.x;
+                const step = Math.abs(x.getPixelForValue(1) - x.getPixelForValue(0)) || 0;
+                const ctx = chart.ctx;
+
+                ctx.save();
+                bands.forEach((band) => {
+                    const left = Math.max(area.left, x.getPixelForValue(band.From) - step / 2);
+                    const right = Math.min(area.right, x.getPixelForValue(band.To) + step / 2);
+                    if (right <= left) {
+                        return;
+                    }
+                    ctx.fillStyle = fillColor;
+                    ctx.fillRect(left, area.top, right - left, area.bottom - area.top);
+                    ctx.fillStyle = labelColor;
+                    ctx.font = '11px -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, sans-serif';
+                    ctx.textAlign = 'left';
+                    ctx.textBaseline = 'top';
+                    ctx.fillText('⚡ ' + band.Name, left + 4, area.top + 4);
+                });
+                ctx.restore();
+            }
+        };
+    }
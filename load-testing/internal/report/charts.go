@@ -0,0 +1,163 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"load-test/internal/models"
+)
+
+type ChartSeries struct {
+	Name   string
+	Points []ChartPoint
+}
+
+type ChartPoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+func burnRateChart(overall models.SLOResult, endpoints []models.SLOResult) []ChartSeries {
+	if len(overall.BurnRate) == 0 {
+		return nil
+	}
+	start := overall.BurnRate[0].Timestamp
+
+	toSeries := func(name string, result models.SLOResult) ChartSeries {
+		series := ChartSeries{Name: name}
+		for _, point := range result.BurnRate {
+			series.Points = append(series.Points, ChartPoint{
+				X: point.Timestamp.Sub(start).Minutes(),
+				Y: point.BurnRate,
+			})
+		}
+		return series
+	}
+
+	chart := []ChartSeries{toSeries("overall", overall)}
+	for _, result := range endpoints {
+		chart = append(chart, toSeries(result.Name, result))
+	}
+	return chart
+}
+
+func capacityChart(result models.CapacityResult) []ChartSeries {
+	if len(result.Steps) == 0 {
+		return nil
+	}
+
+	p95 := ChartSeries{Name: "P95 (ms)"}
+	p99 := ChartSeries{Name: "P99 (ms)"}
+	throughput := ChartSeries{Name: "Throughput (req/s)"}
+	for _, step := range result.Steps {
+		p95.Points = append(p95.Points, ChartPoint{X: step.TargetRate, Y: step.P95Duration})
+		p99.Points = append(p99.Points, ChartPoint{X: step.TargetRate, Y: step.P99Duration})
+		throughput.Points = append(throughput.Points, ChartPoint{X: step.TargetRate, Y: step.Throughput})
+	}
+	return []ChartSeries{p95, p99, throughput}
+}
+
+func soakCharts(soak models.SoakReport, endpoints []string) ([]ChartSeries, []ChartSeries) {
+	if len(soak.Overall.Windows) == 0 {
+		return nil, nil
+	}
+	start := soak.Overall.Windows[0].Start
+
+	toSeries := func(name string, windows []models.SoakWindow, value func(models.SoakWindow) float64) ChartSeries {
+		series := ChartSeries{Name: name}
+		for _, window := range windows {
+			series.Points = append(series.Points, ChartPoint{
+				X: window.Start.Sub(start).Minutes(),
+				Y: value(window),
+			})
+		}
+		return series
+	}
+	p95 := func(w models.SoakWindow) float64 { return w.P95Duration }
+	errorRate := func(w models.SoakWindow) float64 { return w.ErrorRate }
+
+	latency := []ChartSeries{toSeries("overall", soak.Overall.Windows, p95)}
+	errors := []ChartSeries{toSeries("overall", soak.Overall.Windows, errorRate)}
+	for _, endpoint := range endpoints {
+		if series, exists := soak.ByEndpoint[endpoint]; exists {
+			latency = append(latency, toSeries(endpoint, series.Windows, p95))
+			errors = append(errors, toSeries(endpoint, series.Windows, errorRate))
+		}
+	}
+	return latency, errors
+}
+
+func resourceChart(soak models.SoakReport) []ChartSeries {
+	if len(soak.Resources) == 0 || len(soak.Overall.Windows) == 0 {
+		return nil
+	}
+	start := soak.Overall.Windows[0].Start
+
+	memory := ChartSeries{Name: "MongoDB resident (MB)"}
+	health := ChartSeries{Name: "/health latency (ms)"}
+	for _, sample := range soak.Resources {
+		x := sample.Timestamp.Sub(start).Minutes()
+		if sample.ResidentMB > 0 {
+			memory.Points = append(memory.Points, ChartPoint{X: x, Y: sample.ResidentMB})
+		}
+		if sample.HealthStatus > 0 {
+			health.Points = append(health.Points, ChartPoint{X: x, Y: sample.HealthLatencyMs})
+		}
+	}
+	return []ChartSeries{memory, health}
+}
+
+type FaultBand struct {
+	Name     string
+	From     int
+	To       int
+	Start    string
+	End      string
+	Injected int
+	Summary  string
+}
+
+func faultBands(timeSeries TimeSeriesData, faultLog *models.FaultLog) []FaultBand {
+	if faultLog == nil || timeSeries.BucketSize <= 0 || len(timeSeries.Timestamps) == 0 {
+		return nil
+	}
+	last := len(timeSeries.Timestamps) - 1
+
+	var bands []FaultBand
+	for _, phase := range faultLog.Phases {
+		injected := 0
+		var parts []string
+		for _, fault := range phase.Injected {
+			injected += fault.Count
+			parts = append(parts, fmt.Sprintf("%s %s ×%d", fault.Type, fault.Route, fault.Count))
+		}
+		if injected == 0 {
+			continue
+		}
+
+		from := int(phase.Start.Sub(timeSeries.Start) / timeSeries.BucketSize)
+		to := int(phase.End.Sub(timeSeries.Start) / timeSeries.BucketSize)
+		if to < 0 || from > last {
+			continue
+		}
+		if from < 0 {
+			from = 0
+		}
+		if to > last {
+			to = last
+		}
+
+		sort.Strings(parts)
+		bands = append(bands, FaultBand{
+			Name:     phase.Name,
+			From:     from,
+			To:       to,
+			Start:    phase.Start.Format("15:04:05"),
+			End:      phase.End.Format("15:04:05"),
+			Injected: injected,
+			Summary:  strings.Join(parts, ", "),
+		})
+	}
+	return bands
+}
@@ -0,0 +1,96 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"load-test/internal/loadtest/metrics"
+	"load-test/internal/models"
+)
+
+type CheckResult struct {
+	Name      string
+	Endpoint  string
+	Metric    string
+	Threshold float64
+	Actual    float64
+	Passed    bool
+	Message   string
+}
+
+func EvaluateChecks(reportData ReportData) []CheckResult {
+	checks := []CheckResult{sloCheck("overall", reportData.SLO.Overall)}
+
+	for _, result := range reportData.SLOList {
+		checks = append(checks, sloCheck(result.Name, result))
+	}
+
+	if reportData.Metadata != nil && reportData.Metadata.Soak != nil {
+		soak := reportData.Metadata.Soak
+		for _, series := range reportData.SoakSeries {
+			checks = append(checks, driftCheck(series.Name, series.Trends, soak))
+		}
+		if len(soak.ResourceTrends) > 0 {
+			checks = append(checks, driftCheck("resources", soak.ResourceTrends, soak))
+		}
+	}
+
+	return checks
+}
+
+func CountFailedChecks(checks []CheckResult) int {
+	failed := 0
+	for _, check := range checks {
+		if !check.Passed {
+			failed++
+		}
+	}
+	return failed
+}
+
+func sloCheck(endpoint string, result models.SLOResult) CheckResult {
+	check := CheckResult{
+		Name:      fmt.Sprintf("%s SLO: %.2f%% of requests succeed within %.0fms", endpoint, result.SuccessObjective, result.LatencyTargetMs),
+		Endpoint:  endpoint,
+		Metric:    "slo_compliance",
+		Threshold: result.SuccessObjective,
+		Actual:    result.Compliance,
+		Passed:    result.Met,
+	}
+	if !check.Passed {
+		check.Message = fmt.Sprintf("compliance %.2f%% is below the %.2f%% objective (error budget consumed %.0f%%, apdex %.2f)",
+			result.Compliance, result.SuccessObjective, result.BudgetConsumed, result.Apdex)
+	}
+	return check
+}
+
+func driftCheck(name string, trends []models.TrendResult, soak *models.SoakReport) CheckResult {
+	check := CheckResult{
+		Name:      fmt.Sprintf("%s soak: no significant drift above %.0f%%/h", name, soak.DriftThreshold),
+		Endpoint:  name,
+		Metric:    "soak_drift",
+		Threshold: soak.DriftThreshold,
+		Passed:    true,
+	}
+
+	var drifting []string
+	for _, trend := range trends {
+		if !trend.Drifting {
+			continue
+		}
+		check.Passed = false
+		if trend.Metric == metrics.TrendErrorRate {
+			drifting = append(drifting, fmt.Sprintf("%s %+.2f pp/h", trend.Metric, trend.SlopePerHour))
+		} else {
+			drifting = append(drifting, fmt.Sprintf("%s %+.1f%%/h", trend.Metric, trend.ChangePerHour))
+			if math.Abs(trend.ChangePerHour) > math.Abs(check.Actual) {
+				check.Actual = trend.ChangePerHour
+			}
+		}
+	}
+	if !check.Passed {
+		check.Message = "significant drift: " + strings.Join(drifting, ", ")
+	}
+	return check
+}
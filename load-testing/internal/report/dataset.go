@@ -0,0 +1,271 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+
+	"load-test/internal/models"
+)
+
+type DatasetReportData struct {
+	GeneratedAt string
+	Assets      AssetData `json:"-"`
+	Stats       models.DatasetStats
+}
+
+func GenerateDatasetReport(stats models.DatasetStats, outputPath string, mode AssetMode) error {
+	assets, err := loadAssets(mode)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer file.Close()
+
+	return RenderDatasetHTML(file, DatasetReportData{
+		GeneratedAt: stats.AnalyzedAt.Format("2006-01-02 15:04:05"),
+		Assets:      assets,
+		Stats:       stats,
+	})
+}
+
+func RenderDatasetHTML(w io.Writer, data DatasetReportData) error {
+	tmpl, err := template.New("dataset").Funcs(template.FuncMap{
+		"toJSON": toJSON,
+		"percent": func(part, total int64) float64 {
+			if total == 0 {
+				return 0
+			}
+			return float64(part) / float64(total) * 100
+		},
+		"mulf": func(a, b float64) float64 {
+			return a * b
+		},
+	}).Parse(datasetTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	if err := tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+	return nil
+}
+
+const datasetTemplate = `
+<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Dataset Report - Movie Recommendation System</title>
+    {{if .Assets.Inline}}
+    <script>{{.Assets.ChartJS}}</script>
+    {{else}}
+    <script src="{{.Assets.ChartJSURL}}"></script>
+    {{end}}
+    <style>{{.Assets.CSS}}</style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>🎬 Movie Recommendation System</h1>
+            <div class="subtitle">Dataset Report — {{.Stats.Database}}{{if .Stats.Generation}} (generation {{.Stats.Generation}}){{end}}</div>
+            <div class="timestamp">
+                {{if .Stats.Dataset}}Dataset: {{.Stats.Dataset}} · {{end}}{{if .Stats.Source}}Source: {{.Stats.Source}} · {{end}}Analyzed: {{.GeneratedAt}}
+            </div>
+        </div>
+
+        <div class="summary-cards">
+            <div class="card">
+                <div class="card-title">Users</div>
+                <div class="card-value">{{.Stats.Users}}</div>
+                <div class="card-subtitle">{{.Stats.ColdStartUsers}} cold-start ({{printf "%.1f" (percent .Stats.ColdStartUsers .Stats.Users)}}%)</div>
+            </div>
+            <div class="card">
+                <div class="card-title">Movies</div>
+                <div class="card-value">{{.Stats.Movies}}</div>
+                <div class="card-subtitle">{{.Stats.ColdStartMovies}} never interacted with ({{printf "%.1f" (percent .Stats.ColdStartMovies .Stats.Movies)}}%)</div>
+            </div>
+            <div class="card">
+                <div class="card-title">Interactions</div>
+                <div class="card-value">{{.Stats.Interactions}}</div>
+                <div class="card-subtitle">{{.Stats.UserMoviePairs}} distinct user-movie pairs</div>
+            </div>
+            <div class="card {{if gt .Stats.Sparsity 0.999}}warning{{end}}">
+                <div class="card-title">Sparsity</div>
+                <div class="card-value">{{printf "%.4f" (mulf .Stats.Sparsity 100.0)}}%</div>
+                <div class="card-subtitle">density {{printf "%.3g" .Stats.Density}}</div>
+            </div>
+            <div class="card {{if or .Stats.UnknownUsers .Stats.UnknownMovies}}danger{{end}}">
+                <div class="card-title">Dangling References</div>
+                <div class="card-value">{{.Stats.UnknownUsers}} / {{.Stats.UnknownMovies}}</div>
+                <div class="card-subtitle">users / movies outside the analyzed set</div>
+            </div>
+        </div>
+
+        <div class="table-container">
+            <div class="chart-title">📐 Interaction Distribution</div>
+            <table>
+                <thead>
+                    <tr>
+                        <th></th>
+                        <th>Mean</th>
+                        <th>Median</th>
+                        <th>P90</th>
+                        <th>P99</th>
+                        <th>Max</th>
+                        <th>Gini</th>
+                        <th>Top 1% share</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{with .Stats.PerUser}}
+                    <tr>
+                        <td><strong>Per user</strong></td>
+                        <td>{{printf "%.2f" .Mean}}</td>
+                        <td>{{.Median}}</td>
+                        <td>{{.P90}}</td>
+                        <td>{{.P99}}</td>
+                        <td>{{.Max}}</td>
+                        <td>{{printf "%.3f" .Gini}}</td>
+                        <td>{{printf "%.1f" (mulf .TopShare 100.0)}}%</td>
+                    </tr>
+                    {{end}}
+                    {{with .Stats.PerMovie}}
+                    <tr>
+                        <td><strong>Per movie</strong></td>
+                        <td>{{printf "%.2f" .Mean}}</td>
+                        <td>{{.Median}}</td>
+                        <td>{{.P90}}</td>
+                        <td>{{.P99}}</td>
+                        <td>{{.Max}}</td>
+                        <td>{{printf "%.3f" .Gini}}</td>
+                        <td>{{printf "%.1f" (mulf .TopShare 100.0)}}%</td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </div>
+
+        <div class="chart-grid">
+            <div class="chart-container">
+                <div class="chart-title">👤 Interactions per User</div>
+                <div class="chart-subtitle">Number of users per interaction-count bucket</div>
+                <div class="chart-wrapper">
+                    <canvas id="perUserChart"></canvas>
+                </div>
+            </div>
+            <div class="chart-container">
+                <div class="chart-title">🎞️ Interactions per Movie</div>
+                <div class="chart-subtitle">Number of movies per interaction-count bucket</div>
+                <div class="chart-wrapper">
+                    <canvas id="perMovieChart"></canvas>
+                </div>
+            </div>
+            <div class="chart-container">
+                <div class="chart-title">🧩 Interaction Types</div>
+                <div class="chart-wrapper">
+                    <canvas id="typeChart"></canvas>
+                </div>
+            </div>
+            <div class="chart-container">
+                <div class="chart-title">⭐ Rating Histogram</div>
+                <div class="chart-wrapper">
+                    <canvas id="ratingChart"></canvas>
+                </div>
+            </div>
+        </div>
+
+        <div class="table-container">
+            <div class="chart-title">🎭 Genres</div>
+            <table>
+                <thead>
+                    <tr>
+                        <th>Genre</th>
+                        <th>Movies</th>
+                        <th>Interactions</th>
+                        <th>Share of Interactions</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{range .Stats.Genres}}
+                    <tr>
+                        <td><strong>{{.Genre}}</strong></td>
+                        <td>{{.Movies}}</td>
+                        <td>{{.Interactions}}</td>
+                        <td>{{printf "%.1f" (percent .Interactions $.Stats.Interactions)}}%</td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </div>
+
+        <div class="footer">
+            <p>Generated by Movie Recommendation Performance Testing Suite</p>
+            <p style="margin-top: 10px; color: #999;">
+                Report generated on {{.GeneratedAt}}
+            </p>
+        </div>
+    </div>
+
+    <script>
+        const chartColors = {
+            primary: '#667eea',
+            secondary: '#764ba2',
+            success: '#10b981',
+            warning: '#f59e0b',
+            grid: '#e9ecef'
+        };
+
+        const barOptions = {
+            responsive: true,
+            maintainAspectRatio: false,
+            plugins: {
+                legend: {
+                    display: false
+                }
+            },
+            scales: {
+                y: {
+                    beginAtZero: true,
+                    grid: {
+                        color: chartColors.grid
+                    }
+                },
+                x: {
+                    grid: {
+                        color: chartColors.grid
+                    }
+                }
+            }
+        };
+
+        function barChart(id, counts, label, color) {
+            new Chart(document.getElementById(id), {
+                type: 'bar',
+                data: {
+                    labels: counts.map(c => c.Name),
+                    datasets: [{
+                        label: label,
+                        data: counts.map(c => c.Count),
+                        backgroundColor: color
+                    }]
+                },
+                options: barOptions
+            });
+        }
+
+        barChart('perUserChart', {{toJSON .Stats.PerUser.Buckets}} || [], 'Users', chartColors.primary);
+        barChart('perMovieChart', {{toJSON .Stats.PerMovie.Buckets}} || [], 'Movies', chartColors.secondary);
+        barChart('typeChart', {{toJSON .Stats.Types}} || [], 'Interactions', chartColors.success);
+        barChart('ratingChart', {{toJSON .Stats.Ratings}} || [], 'Ratings', chartColors.warning);
+    </script>
+</body>
+</html>
+`
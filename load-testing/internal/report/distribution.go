@@ -0,0 +1,245 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/montanaflynn/stats"
+	"load-test/internal/loadtest/metrics"
+	"load-test/internal/models"
+)
+
+const (
+	histogramBucketsPerDecade = 10
+	heatmapBucketsPerDecade   = 8
+)
+
+var SpectrumPercentiles = []float64{50, 75, 90, 95, 99, 99.5, 99.9, 99.99}
+
+type HistogramData struct {
+	Endpoint string
+	Count    int
+	Labels   []string
+	Counts   []int
+	Percent  []float64
+}
+
+type HeatmapData struct {
+	Name          string
+	TimeLabels    []string
+	LatencyLabels []string
+	Cells         [][]int
+	MaxCount      int
+}
+
+type SpectrumData struct {
+	Labels []string
+	Series []SpectrumSeries
+}
+
+type SpectrumSeries struct {
+	Name   string
+	Values []float64
+}
+
+func GenerateLatencyHistograms(metricsData []models.Metric, endpoints []string) []HistogramData {
+	if len(metricsData) == 0 {
+		return nil
+	}
+
+	overall := metrics.NewHistogram(histogramBucketsPerDecade)
+	byEndpoint := make(map[string]*metrics.Histogram)
+
+	for _, m := range metricsData {
+		durationMs := m.Duration.Seconds() * 1000
+		overall.Add(durationMs)
+
+		h, exists := byEndpoint[m.Endpoint]
+		if !exists {
+			h = metrics.NewHistogram(histogramBucketsPerDecade)
+			byEndpoint[m.Endpoint] = h
+		}
+		h.Add(durationMs)
+	}
+
+	minIdx, maxIdx := overall.Range()
+
+	labels := make([]string, 0, maxIdx-minIdx+1)
+	for idx := minIdx; idx <= maxIdx; idx++ {
+		_, upper := overall.BucketBounds(idx)
+		labels = append(labels, "≤"+formatMs(upper))
+	}
+
+	histograms := make([]HistogramData, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		h, exists := byEndpoint[endpoint]
+		if !exists {
+			continue
+		}
+
+		counts := make([]int, 0, len(labels))
+		percent := make([]float64, 0, len(labels))
+		for idx := minIdx; idx <= maxIdx; idx++ {
+			counts = append(counts, h.Counts[idx])
+			percent = append(percent, float64(h.Counts[idx])/float64(h.Total)*100)
+		}
+
+		histograms = append(histograms, HistogramData{
+			Endpoint: endpoint,
+			Count:    h.Total,
+			Labels:   labels,
+			Counts:   counts,
+			Percent:  percent,
+		})
+	}
+
+	return histograms
+}
+
+func GenerateHeatmaps(metricsData []models.Metric, endpoints []string, bucketSize time.Duration) []HeatmapData {
+	if len(metricsData) == 0 {
+		return nil
+	}
+
+	bucketSeconds := int64(bucketSize.Seconds())
+	if bucketSeconds < 1 {
+		bucketSeconds = 1
+	}
+
+	scale := metrics.NewHistogram(heatmapBucketsPerDecade)
+
+	minSlot := metricsData[0].Timestamp.Unix() / bucketSeconds
+	maxSlot := minSlot
+	for _, m := range metricsData {
+		slot := m.Timestamp.Unix() / bucketSeconds
+		if slot < minSlot {
+			minSlot = slot
+		}
+		if slot > maxSlot {
+			maxSlot = slot
+		}
+		scale.Add(m.Duration.Seconds() * 1000)
+	}
+
+	minIdx, maxIdx := scale.Range()
+
+	timeLabels := make([]string, 0, maxSlot-minSlot+1)
+	for slot := minSlot; slot <= maxSlot; slot++ {
+		timeLabels = append(timeLabels, time.Unix(slot*bucketSeconds, 0).Format("15:04:05"))
+	}
+
+	latencyLabels := make([]string, 0, maxIdx-minIdx+1)
+	for idx := minIdx; idx <= maxIdx; idx++ {
+		_, upper := scale.BucketBounds(idx)
+		latencyLabels = append(latencyLabels, formatMs(upper))
+	}
+
+	newHeatmap := func(name string) *HeatmapData {
+		cells := make([][]int, len(latencyLabels))
+		for i := range cells {
+			cells[i] = make([]int, len(timeLabels))
+		}
+		return &HeatmapData{
+			Name:          name,
+			TimeLabels:    timeLabels,
+			LatencyLabels: latencyLabels,
+			Cells:         cells,
+		}
+	}
+
+	all := newHeatmap("All endpoints")
+	byEndpoint := make(map[string]*HeatmapData)
+
+	for _, m := range metricsData {
+		row := scale.BucketIndex(m.Duration.Seconds()*1000) - minIdx
+		col := int(m.Timestamp.Unix()/bucketSeconds - minSlot)
+
+		all.Cells[row][col]++
+
+		hm, exists := byEndpoint[m.Endpoint]
+		if !exists {
+			hm = newHeatmap(m.Endpoint)
+			byEndpoint[m.Endpoint] = hm
+		}
+		hm.Cells[row][col]++
+	}
+
+	heatmaps := []HeatmapData{*all}
+	for _, endpoint := range endpoints {
+		if hm, exists := byEndpoint[endpoint]; exists {
+			heatmaps = append(heatmaps, *hm)
+		}
+	}
+
+	for i := range heatmaps {
+		for _, row := range heatmaps[i].Cells {
+			for _, count := range row {
+				if count > heatmaps[i].MaxCount {
+					heatmaps[i].MaxCount = count
+				}
+			}
+		}
+	}
+
+	return heatmaps
+}
+
+func GeneratePercentileSpectrum(metricsData []models.Metric, endpoints []string) SpectrumData {
+	labels := make([]string, len(SpectrumPercentiles))
+	for i, p := range SpectrumPercentiles {
+		labels[i] = fmt.Sprintf("P%g", p)
+	}
+
+	spectrum := SpectrumData{Labels: labels}
+	if len(metricsData) == 0 {
+		return spectrum
+	}
+
+	var all []float64
+	byEndpoint := make(map[string][]float64)
+	for _, m := range metricsData {
+		durationMs := m.Duration.Seconds() * 1000
+		all = append(all, durationMs)
+		byEndpoint[m.Endpoint] = append(byEndpoint[m.Endpoint], durationMs)
+	}
+
+	spectrum.Series = append(spectrum.Series, SpectrumSeries{
+		Name:   "All endpoints",
+		Values: percentileValues(all),
+	})
+
+	for _, endpoint := range endpoints {
+		if durations, exists := byEndpoint[endpoint]; exists {
+			spectrum.Series = append(spectrum.Series, SpectrumSeries{
+				Name:   endpoint,
+				Values: percentileValues(durations),
+			})
+		}
+	}
+
+	return spectrum
+}
+
+func percentileValues(durations []float64) []float64 {
+	sort.Float64s(durations)
+
+	values := make([]float64, len(SpectrumPercentiles))
+	for i, p := range SpectrumPercentiles {
+		values[i], _ = stats.Percentile(durations, p)
+	}
+	return values
+}
+
+func formatMs(value float64) string {
+	switch {
+	case value >= 1000:
+		return fmt.Sprintf("%.1fs", value/1000)
+	case value >= 10:
+		return fmt.Sprintf("%.0fms", value)
+	case value >= 1:
+		return fmt.Sprintf("%.1fms", value)
+	default:
+		return fmt.Sprintf("%.2fms", value)
+	}
+}
@@ -0,0 +1,294 @@
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"load-test/internal/loadtest/metrics"
+	"load-test/internal/models"
+)
+
+type Format string
+
+const (
+	FormatHTML     Format = "html"
+	FormatMarkdown Format = "md"
+	FormatJUnit    Format = "junit"
+	FormatJSON     Format = "json"
+)
+
+func ParseFormat(value string) (Format, error) {
+	switch Format(value) {
+	case FormatHTML, FormatMarkdown, FormatJUnit, FormatJSON:
+		return Format(value), nil
+	default:
+		return "", fmt.Errorf("unknown report format %q (expected html|md|junit|json)", value)
+	}
+}
+
+func (f Format) Extension() string {
+	switch f {
+	case FormatMarkdown:
+		return ".md"
+	case FormatJUnit:
+		return ".xml"
+	case FormatJSON:
+		return ".json"
+	default:
+		return ".html"
+	}
+}
+
+func Render(w io.Writer, format Format, reportData ReportData) error {
+	switch format {
+	case FormatHTML, "":
+		return RenderHTML(w, reportData)
+	case FormatMarkdown:
+		return RenderMarkdown(w, reportData)
+	case FormatJUnit:
+		return RenderJUnit(w, reportData)
+	case FormatJSON:
+		return RenderJSON(w, reportData)
+	default:
+		return fmt.Errorf("unsupported report format %q", format)
+	}
+}
+
+func RenderJSON(w io.Writer, reportData ReportData) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(reportData); err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	return nil
+}
+
+func RenderMarkdown(w io.Writer, reportData ReportData) error {
+	var b strings.Builder
+	stats := reportData.Stats
+
+	failed := CountFailedChecks(reportData.Checks)
+	status := "✅ All checks passed"
+	if failed > 0 {
+		status = fmt.Sprintf("❌ %d of %d checks failed", failed, len(reportData.Checks))
+	}
+
+	fmt.Fprintf(&b, "## 🎬 Performance Test Report\n\n")
+	fmt.Fprintf(&b, "_Generated: %s_ — **%s**\n\n", reportData.GeneratedAt, status)
+
+	overall := reportData.SLO.Overall
+	fmt.Fprintf(&b, "| Grade | Apdex | SLO | Requests | Success | Throughput | Mean | P95 | P99 |\n")
+	fmt.Fprintf(&b, "|:---:|---:|---:|---:|---:|---:|---:|---:|---:|\n")
+	fmt.Fprintf(&b, "| **%s** | %.2f | %.2f%% / %.2f%% | %d | %.2f%% | %.1f req/s | %.0f ms | %.0f ms | %.0f ms |\n\n",
+		reportData.Grade, overall.Apdex, overall.Compliance, overall.SuccessObjective,
+		stats.TotalRequests, stats.SuccessRate, stats.RequestsPerSecond,
+		stats.MeanDuration, stats.P95Duration, stats.P99Duration)
+
+	if len(reportData.EndpointList) > 0 {
+		fmt.Fprintf(&b, "### Endpoints\n\n")
+		fmt.Fprintf(&b, "| Endpoint | Requests | Success | Avg (ms) | P95 (ms) | Target (ms) | Apdex | Budget Left |\n")
+		fmt.Fprintf(&b, "|---|---:|---:|---:|---:|---:|---:|---:|\n")
+		for _, ep := range reportData.EndpointList {
+			slo := reportData.SLO.ByEndpoint[ep.Endpoint]
+			fmt.Fprintf(&b, "| `%s` | %d | %.2f%% | %.2f | %.2f | %.0f | %.2f | %.0f%% |\n",
+				ep.Endpoint, ep.Count, ep.SuccessRate, ep.AvgDuration, ep.P95Duration,
+				slo.LatencyTargetMs, slo.Apdex, slo.BudgetRemaining)
+		}
+		fmt.Fprintf(&b, "\n")
+	}
+
+	if reportData.Metadata != nil && reportData.Metadata.Capacity != nil {
+		capacity := reportData.Metadata.Capacity
+		fmt.Fprintf(&b, "### Capacity\n\n")
+		if capacity.MaxSustainableRate > 0 {
+			fmt.Fprintf(&b, "Max sustainable rate: **%.1f it/s**", capacity.MaxSustainableRate)
+		} else {
+			fmt.Fprintf(&b, "No tested rate met the SLO")
+		}
+		if capacity.KneeRate > 0 {
+			fmt.Fprintf(&b, " — latency knee at ~%.1f it/s", capacity.KneeRate)
+		}
+		fmt.Fprintf(&b, " (%s strategy)\n\n", capacity.Strategy)
+		fmt.Fprintf(&b, "| Target (it/s) | Throughput | Success | P95 (ms) | P99 (ms) | Apdex | Dropped | Result |\n")
+		fmt.Fprintf(&b, "|---:|---:|---:|---:|---:|---:|---:|---|\n")
+		for _, step := range capacity.Steps {
+			result := "✅"
+			if !step.Passed {
+				result = "❌ " + markdownEscape(step.Reason)
+			}
+			fmt.Fprintf(&b, "| %.1f | %.1f req/s | %.2f%% | %.1f | %.1f | %.2f | %d | %s |\n",
+				step.TargetRate, step.Throughput, step.SuccessRate, step.P95Duration, step.P99Duration,
+				step.Apdex, step.DroppedIterations, result)
+		}
+		fmt.Fprintf(&b, "\n")
+	}
+
+	if reportData.Metadata != nil && reportData.Metadata.Soak != nil {
+		soak := reportData.Metadata.Soak
+		verdict := "✅ no significant drift"
+		if soak.Drifting {
+			verdict = "❌ significant drift detected"
+		}
+		fmt.Fprintf(&b, "### Soak Trends\n\n")
+		fmt.Fprintf(&b, "%s (%.0fs windows every %.0fs, threshold %.0f%%/h, errors %.1f pp/h)\n\n", verdict, soak.WindowSeconds, soak.StepSeconds, soak.DriftThreshold, soak.ErrorDriftThreshold)
+		fmt.Fprintf(&b, "| Series | Metric | Baseline | Trend | R² | Status |\n")
+		fmt.Fprintf(&b, "|---|---|---:|---:|---:|---|\n")
+		writeTrends := func(name string, trends []models.TrendResult, onlyDrifting bool) {
+			for _, trend := range trends {
+				if onlyDrifting && !trend.Drifting {
+					continue
+				}
+				change := fmt.Sprintf("%+.1f%%/h", trend.ChangePerHour)
+				if trend.Metric == metrics.TrendErrorRate {
+					change = fmt.Sprintf("%+.2f pp/h", trend.SlopePerHour)
+				}
+				status := "flat"
+				if trend.Drifting {
+					status = "❌ drifting"
+				} else if trend.Significant {
+					status = "trend"
+				}
+				fmt.Fprintf(&b, "| `%s` | %s | %.2f | %s | %.2f | %s |\n", name, trend.Metric, trend.Baseline, change, trend.RSquared, status)
+			}
+		}
+		for i, series := range reportData.SoakSeries {
+			writeTrends(series.Name, series.Trends, i > 0)
+		}
+		writeTrends("resources", soak.ResourceTrends, false)
+		fmt.Fprintf(&b, "\n")
+	}
+
+	if len(reportData.FaultBands) > 0 {
+		fmt.Fprintf(&b, "### Injected Faults\n\n")
+		fmt.Fprintf(&b, "| Phase | Window | Injected | Faults |\n")
+		fmt.Fprintf(&b, "|---|---|---:|---|\n")
+		for _, band := range reportData.FaultBands {
+			fmt.Fprintf(&b, "| %s | %s – %s | %d | %s |\n", markdownEscape(band.Name), band.Start, band.End, band.Injected, markdownEscape(band.Summary))
+		}
+		fmt.Fprintf(&b, "\n")
+	}
+
+	if failed > 0 {
+		fmt.Fprintf(&b, "### Failed Checks\n\n")
+		for _, check := range reportData.Checks {
+			if !check.Passed {
+				fmt.Fprintf(&b, "- ❌ **%s** — %s\n", check.Name, check.Message)
+			}
+		}
+		fmt.Fprintf(&b, "\n")
+	}
+
+	if len(reportData.ErrorTaxonomy) > 0 {
+		fmt.Fprintf(&b, "### Error Taxonomy\n\n")
+		fmt.Fprintf(&b, "| Category | Count | Sample Response |\n|---|---:|---|\n")
+		for _, category := range reportData.ErrorTaxonomy {
+			sample := ""
+			if len(category.Samples) > 0 {
+				sample = "`" + markdownEscape(strings.ReplaceAll(category.Samples[0], "`", "'")) + "`"
+			}
+			fmt.Fprintf(&b, "| `%s` | %d | %s |\n", category.Category, category.Count, sample)
+		}
+		fmt.Fprintf(&b, "\n")
+	}
+
+	if len(reportData.ErrorList) > 0 {
+		fmt.Fprintf(&b, "<details><summary>⚠️ Top errors (%d distinct)</summary>\n\n", len(reportData.ErrorList))
+		fmt.Fprintf(&b, "| Error | Count |\n|---|---:|\n")
+		for i, e := range reportData.ErrorList {
+			if i == 10 {
+				break
+			}
+			fmt.Fprintf(&b, "| %s | %d |\n", markdownEscape(e.Message), e.Count)
+		}
+		fmt.Fprintf(&b, "\n</details>\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func markdownEscape(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+func RenderJUnit(w io.Writer, reportData ReportData) error {
+	suites := make(map[string]*junitTestSuite)
+	var order []string
+
+	for _, check := range reportData.Checks {
+		suite, exists := suites[check.Endpoint]
+		if !exists {
+			suite = &junitTestSuite{Name: check.Endpoint, Timestamp: strings.Replace(reportData.GeneratedAt, " ", "T", 1)}
+			suites[check.Endpoint] = suite
+			order = append(order, check.Endpoint)
+		}
+
+		testCase := junitTestCase{
+			Name:      check.Name,
+			ClassName: "performance." + check.Metric,
+		}
+		if !check.Passed {
+			testCase.Failure = &junitFailure{
+				Message: check.Message,
+				Type:    check.Metric,
+				Body:    fmt.Sprintf("threshold=%g actual=%.2f", check.Threshold, check.Actual),
+			}
+			suite.Failures++
+		}
+
+		suite.Tests++
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	result := junitTestSuites{Name: "performance"}
+	for _, name := range order {
+		suite := suites[name]
+		result.Tests += suite.Tests
+		result.Failures += suite.Failures
+		result.Suites = append(result.Suites, *suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return fmt.Errorf("failed to encode junit report: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
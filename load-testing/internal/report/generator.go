@@ -4,21 +4,51 @@ import (
 	"encoding/json"
 	"fmt"
 	"html/template"
+	"io"
 	"os"
+	"reflect"
+	"sort"
 	"time"
 
+	"load-test/internal/config"
 	"load-test/internal/loadtest/metrics"
 	"load-test/internal/models"
 )
 
+type Options struct {
+	Assets   AssetMode
+	SLO      config.SLOConfig
+	Metadata *models.RunMetadata
+	Faults   *models.FaultLog
+}
+
 type ReportData struct {
 	GeneratedAt    string
+	Assets         AssetData `json:"-"`
 	Stats          models.TestStats
 	TimeSeries     TimeSeriesData
 	PercentileData PercentileData
 	ScenarioList   []ScenarioData
 	EndpointList   []EndpointData
 	ErrorList      []ErrorData
+	StatusCodes    []StatusCodeData
+	ErrorTaxonomy  []ErrorCategoryData
+	Histograms     []HistogramData
+	Heatmaps       []HeatmapData
+	Spectrum       SpectrumData
+	SLO            models.SLOReport
+	SLOList        []models.SLOResult
+	Grade          string
+	BurnRateChart  []ChartSeries
+	Checks         []CheckResult
+	Metadata       *models.RunMetadata
+	CapacityChart  []ChartSeries
+	SoakSeries     []models.SoakSeries
+	SoakLatency    []ChartSeries
+	SoakErrors     []ChartSeries
+	ResourceChart  []ChartSeries
+	FaultLog       *models.FaultLog
+	FaultBands     []FaultBand
 }
 
 type PercentileData struct {
@@ -41,6 +71,18 @@ type EndpointData struct {
 	SuccessRate float64
 	AvgDuration float64
 	P95Duration float64
+	StatusCodes []StatusCodeData
+}
+
+type StatusCodeData struct {
+	Code  int
+	Count int
+}
+
+type ErrorCategoryData struct {
+	Category string
+	Count    int
+	Samples  []string
 }
 
 type ErrorData struct {
@@ -48,8 +90,34 @@ type ErrorData struct {
 	Count   int
 }
 
-func GenerateHTMLReport(metricsData []models.Metric, outputPath string) error {
+func GenerateHTMLReport(metricsData []models.Metric, outputPath string, opts Options) error {
+	return GenerateReport(metricsData, outputPath, FormatHTML, opts)
+}
+
+func GenerateReport(metricsData []models.Metric, outputPath string, format Format, opts Options) error {
+	reportData := BuildReportData(metricsData, opts)
+	if format == FormatHTML || format == "" {
+		assets, err := loadAssets(opts.Assets)
+		if err != nil {
+			return err
+		}
+		reportData.Assets = assets
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer file.Close()
+
+	return Render(file, format, reportData)
+}
+
+func BuildReportData(metricsData []models.Metric, opts Options) ReportData {
 	stats := metrics.CalculateStats(metricsData)
+	if opts.Metadata != nil && opts.Metadata.Aggregate != nil {
+		stats = opts.Metadata.Aggregate.Stats
+	}
 	timeSeries := GenerateTimeSeries(metricsData, 5*time.Second)
 
 	percentiles := []float64{stats.MinDuration, stats.MedianDuration, stats.P95Duration, stats.P99Duration, stats.MaxDuration}
@@ -78,6 +146,7 @@ func GenerateHTMLReport(metricsData []models.Metric, outputPath string) error {
 			SuccessRate: epStats.SuccessRate,
 			AvgDuration: epStats.AvgDuration,
 			P95Duration: epStats.P95Duration,
+			StatusCodes: statusCodeList(epStats.StatusCodes),
 		})
 	}
 
@@ -113,6 +182,11 @@ func GenerateHTMLReport(metricsData []models.Metric, outputPath string) error {
 		}
 	}
 
+	endpointNames := make([]string, len(endpointList))
+	for i, ep := range endpointList {
+		endpointNames[i] = ep.Endpoint
+	}
+
 	reportData := ReportData{
 		GeneratedAt:    time.Now().Format("2006-01-02 15:04:05"),
 		Stats:          stats,
@@ -121,12 +195,91 @@ func GenerateHTMLReport(metricsData []models.Metric, outputPath string) error {
 		ScenarioList:   scenarioList,
 		EndpointList:   endpointList,
 		ErrorList:      errorList,
+		StatusCodes:    statusCodeList(stats.StatusCodes),
+		ErrorTaxonomy:  errorTaxonomy(stats),
+		Histograms:     GenerateLatencyHistograms(metricsData, endpointNames),
+		Heatmaps:       GenerateHeatmaps(metricsData, endpointNames, 5*time.Second),
+		Spectrum:       GeneratePercentileSpectrum(metricsData, endpointNames),
+	}
+
+	reportData.SLO = metrics.EvaluateSLO(metricsData, opts.SLO)
+	if opts.Metadata != nil && opts.Metadata.Aggregate != nil {
+		reportData.SLO = opts.Metadata.Aggregate.SLO
 	}
+	for _, endpoint := range endpointNames {
+		if result, exists := reportData.SLO.ByEndpoint[endpoint]; exists {
+			reportData.SLOList = append(reportData.SLOList, result)
+		}
+	}
+	reportData.Grade = metrics.ApdexGrade(reportData.SLO.Overall.Apdex)
+	reportData.BurnRateChart = burnRateChart(reportData.SLO.Overall, reportData.SLOList)
 
+	reportData.Metadata = opts.Metadata
+	if opts.Metadata != nil && opts.Metadata.Capacity != nil {
+		reportData.CapacityChart = capacityChart(*opts.Metadata.Capacity)
+	}
+	if opts.Metadata != nil && opts.Metadata.Soak != nil {
+		soak := *opts.Metadata.Soak
+		reportData.SoakSeries = append(reportData.SoakSeries, soak.Overall)
+		for _, endpoint := range endpointNames {
+			if series, exists := soak.ByEndpoint[endpoint]; exists {
+				reportData.SoakSeries = append(reportData.SoakSeries, series)
+			}
+		}
+		reportData.SoakLatency, reportData.SoakErrors = soakCharts(soak, endpointNames)
+		reportData.ResourceChart = resourceChart(soak)
+	}
+
+	reportData.FaultLog = opts.Faults
+	reportData.FaultBands = faultBands(timeSeries, opts.Faults)
+
+	reportData.Checks = EvaluateChecks(reportData)
+
+	return reportData
+}
+
+func statusCodeList(codes map[int]int) []StatusCodeData {
+	list := make([]StatusCodeData, 0, len(codes))
+	for code, count := range codes {
+		list = append(list, StatusCodeData{Code: code, Count: count})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Code < list[j].Code })
+	return list
+}
+
+func errorTaxonomy(stats models.TestStats) []ErrorCategoryData {
+	list := make([]ErrorCategoryData, 0, len(stats.ErrorCategories))
+	for category, count := range stats.ErrorCategories {
+		list = append(list, ErrorCategoryData{
+			Category: category,
+			Count:    count,
+			Samples:  stats.ErrorSamples[category],
+		})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Count > list[j].Count })
+	return list
+}
+
+func toJSON(v interface{}) template.JS {
+	if value := reflect.ValueOf(v); value.Kind() == reflect.Slice && value.IsNil() {
+		return template.JS("[]")
+	}
+	b, _ := json.Marshal(v)
+	return template.JS(b)
+}
+
+func RenderHTML(w io.Writer, reportData ReportData) error {
 	tmpl, err := template.New("report").Funcs(template.FuncMap{
-		"toJSON": func(v interface{}) template.JS {
-			b, _ := json.Marshal(v)
-			return template.JS(b)
+		"toJSON": toJSON,
+		"statusBadge": func(code int) string {
+			switch {
+			case code >= 200 && code < 300:
+				return "badge-success"
+			case code >= 300 && code < 500:
+				return "badge-warning"
+			default:
+				return "badge-danger"
+			}
 		},
 		"mulf": func(a, b float64) float64 {
 			return a * b
@@ -157,13 +310,7 @@ func GenerateHTMLReport(metricsData []models.Metric, outputPath string) error {
 		return fmt.Errorf("failed to parse template: %w", err)
 	}
 
-	file, err := os.Create(outputPath)
-	if err != nil {
-		return fmt.Errorf("failed to create output file: %w", err)
-	}
-	defer file.Close()
-
-	if err := tmpl.Execute(file, reportData); err != nil {
+	if err := tmpl.Execute(w, reportData); err != nil {
 		return fmt.Errorf("failed to execute template: %w", err)
 	}
 
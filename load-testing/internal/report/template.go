@@ -7,285 +7,13 @@ const htmlTemplate = `
     <meta charset="UTF-8">
     <meta name="viewport" content="width=device-width, initial-scale=1.0">
     <title>Performance Test Report - Movie Recommendation System</title>
-    <script src="https://cdn.jsdelivr.net/npm/chart.js@4.4.0/dist/chart.umd.min.js"></script>
-    <style>
-        * {
-            margin: 0;
-            padding: 0;
-            box-sizing: border-box;
-        }
-
-        body {
-            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, Oxygen, Ubuntu, Cantarell, sans-serif;
-            background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
-            color: #333;
-            padding: 20px;
-            min-height: 100vh;
-        }
-
-        .container {
-            max-width: 1400px;
-            margin: 0 auto;
-        }
-
-        .header {
-            background: white;
-            padding: 30px;
-            border-radius: 15px;
-            box-shadow: 0 10px 30px rgba(0,0,0,0.2);
-            margin-bottom: 30px;
-            text-align: center;
-        }
-
-        .header h1 {
-            font-size: 2.5em;
-            color: #667eea;
-            margin-bottom: 10px;
-        }
-
-        .header .subtitle {
-            color: #666;
-            font-size: 1.1em;
-        }
-
-        .header .timestamp {
-            color: #999;
-            font-size: 0.9em;
-            margin-top: 10px;
-        }
-
-        .summary-cards {
-            display: grid;
-            grid-template-columns: repeat(auto-fit, minmax(250px, 1fr));
-            gap: 20px;
-            margin-bottom: 30px;
-        }
-
-        .card {
-            background: white;
-            padding: 25px;
-            border-radius: 15px;
-            box-shadow: 0 5px 15px rgba(0,0,0,0.1);
-            transition: transform 0.3s ease, box-shadow 0.3s ease;
-        }
-
-        .card:hover {
-            transform: translateY(-5px);
-            box-shadow: 0 10px 25px rgba(0,0,0,0.15);
-        }
-
-        .card-title {
-            font-size: 0.9em;
-            color: #666;
-            text-transform: uppercase;
-            letter-spacing: 1px;
-            margin-bottom: 10px;
-        }
-
-        .card-value {
-            font-size: 2.5em;
-            font-weight: bold;
-            color: #667eea;
-            margin-bottom: 5px;
-        }
-
-        .card-subtitle {
-            font-size: 0.9em;
-            color: #999;
-        }
-
-        .card.success .card-value {
-            color: #10b981;
-        }
-
-        .card.warning .card-value {
-            color: #f59e0b;
-        }
-
-        .card.danger .card-value {
-            color: #ef4444;
-        }
-
-        .chart-container {
-            background: white;
-            padding: 30px;
-            border-radius: 15px;
-            box-shadow: 0 5px 15px rgba(0,0,0,0.1);
-            margin-bottom: 30px;
-        }
-
-        .chart-title {
-            font-size: 1.5em;
-            color: #333;
-            margin-bottom: 20px;
-            font-weight: 600;
-        }
-
-        .chart-wrapper {
-            position: relative;
-            height: 400px;
-        }
-
-        .table-container {
-            background: white;
-            padding: 30px;
-            border-radius: 15px;
-            box-shadow: 0 5px 15px rgba(0,0,0,0.1);
-            margin-bottom: 30px;
-            overflow-x: auto;
-        }
-
-        table {
-            width: 100%;
-            border-collapse: collapse;
-        }
-
-        th {
-            background: #f8f9fa;
-            padding: 15px;
-            text-align: left;
-            font-weight: 600;
-            color: #333;
-            border-bottom: 2px solid #e9ecef;
-        }
-
-        td {
-            padding: 15px;
-            border-bottom: 1px solid #e9ecef;
-        }
-
-        tr:hover {
-            background: #f8f9fa;
-        }
-
-        .badge {
-            display: inline-block;
-            padding: 5px 10px;
-            border-radius: 20px;
-            font-size: 0.85em;
-            font-weight: 600;
-        }
-
-        .badge-success {
-            background: #d1fae5;
-            color: #065f46;
-        }
-
-        .badge-warning {
-            background: #fef3c7;
-            color: #92400e;
-        }
-
-        .badge-danger {
-            background: #fee2e2;
-            color: #991b1b;
-        }
-
-        .metric-bar {
-            display: flex;
-            align-items: center;
-            gap: 10px;
-        }
-
-        .metric-bar-fill {
-            flex: 1;
-            height: 8px;
-            background: #e9ecef;
-            border-radius: 4px;
-            overflow: hidden;
-        }
-
-        .metric-bar-value {
-            height: 100%;
-            background: linear-gradient(90deg, #667eea 0%, #764ba2 100%);
-            border-radius: 4px;
-            transition: width 0.3s ease;
-        }
-
-        .metric-bar-label {
-            min-width: 60px;
-            text-align: right;
-            font-weight: 600;
-            color: #667eea;
-        }
-
-        .status-indicator {
-            display: inline-block;
-            width: 10px;
-            height: 10px;
-            border-radius: 50%;
-            margin-right: 8px;
-        }
-
-        .status-indicator.success {
-            background: #10b981;
-        }
-
-        .status-indicator.warning {
-            background: #f59e0b;
-        }
-
-        .status-indicator.danger {
-            background: #ef4444;
-        }
-
-        .footer {
-            background: white;
-            padding: 20px;
-            border-radius: 15px;
-            box-shadow: 0 5px 15px rgba(0,0,0,0.1);
-            text-align: center;
-            color: #666;
-            margin-top: 30px;
-        }
-
-        .performance-grade {
-            text-align: center;
-            padding: 40px;
-            background: white;
-            border-radius: 15px;
-            box-shadow: 0 5px 15px rgba(0,0,0,0.1);
-            margin-bottom: 30px;
-        }
-
-        .grade {
-            font-size: 5em;
-            font-weight: bold;
-            margin-bottom: 10px;
-        }
-
-        .grade.A { color: #10b981; }
-        .grade.B { color: #3b82f6; }
-        .grade.C { color: #f59e0b; }
-        .grade.D { color: #ef4444; }
-
-        @media (max-width: 768px) {
-            .summary-cards {
-                grid-template-columns: 1fr;
-            }
-
-            .header h1 {
-                font-size: 1.8em;
-            }
-
-            .chart-wrapper {
-                height: 300px;
-            }
-        }
-
-        @media print {
-            body {
-                background: white;
-                padding: 0;
-            }
-
-            .card, .chart-container, .table-container {
-                box-shadow: none;
-                border: 1px solid #e9ecef;
-                page-break-inside: avoid;
-            }
-        }
-    </style>
+    {{if .Assets.Inline}}
+    <script>{{.Assets.ChartJS}}</script>
+    {{else}}
+    <script src="{{.Assets.ChartJSURL}}"></script>
+    {{end}}
+    <script>{{.Assets.ReportJS}}</script>
+    <style>{{.Assets.CSS}}</style>
 </head>
 <body>
     <div class="container">
@@ -296,11 +24,14 @@ const htmlTemplate = `
         </div>
 
         <div class="performance-grade">
-            <div class="grade {{if ge .Stats.SuccessRate 99.0}}{{if lt .Stats.P95Duration 200.0}}A{{else}}B{{end}}{{else if ge .Stats.SuccessRate 95.0}}C{{else}}D{{end}}">
-                {{if ge .Stats.SuccessRate 99.0}}{{if lt .Stats.P95Duration 200.0}}A{{else}}B{{end}}{{else if ge .Stats.SuccessRate 95.0}}C{{else}}D{{end}}
-            </div>
+            <div class="grade {{.Grade}}">{{.Grade}}</div>
             <div style="font-size: 1.2em; color: #666;">
-                {{if ge .Stats.SuccessRate 99.0}}{{if lt .Stats.P95Duration 200.0}}Excellent Performance{{else}}Good Performance{{end}}{{else if ge .Stats.SuccessRate 95.0}}Acceptable Performance{{else}}Needs Improvement{{end}}
+                {{.SLO.Overall.Rating}} — Apdex {{printf "%.2f" .SLO.Overall.Apdex}}
+            </div>
+            <div class="grade-detail">
+                <span class="badge {{if .SLO.Overall.Met}}badge-success{{else}}badge-danger{{end}}">SLO {{if .SLO.Overall.Met}}met{{else}}missed{{end}}</span>
+                {{printf "%.2f" .SLO.Overall.Compliance}}% of requests within target (objective {{printf "%.2f" .SLO.Overall.SuccessObjective}}%)
+                · error budget remaining {{printf "%.0f" .SLO.Overall.BudgetRemaining}}%
             </div>
         </div>
 
@@ -375,6 +106,65 @@ const htmlTemplate = `
             </div>
         </div>
 
+        <div class="chart-container">
+            <div class="chart-title">📉 Percentile Spectrum</div>
+            <div class="chart-wrapper">
+                <canvas id="spectrumChart"></canvas>
+            </div>
+        </div>
+
+        <div class="table-container">
+            <div class="chart-title">🧮 Percentile Spectrum (ms)</div>
+            <table>
+                <thead>
+                    <tr>
+                        <th>Endpoint</th>
+                        {{range .Spectrum.Labels}}<th>{{.}}</th>{{end}}
+                    </tr>
+                </thead>
+                <tbody>
+                    {{range .Spectrum.Series}}
+                    <tr>
+                        <td><code>{{.Name}}</code></td>
+                        {{range .Values}}<td>{{printf "%.2f" .}}</td>{{end}}
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </div>
+
+        {{if .Histograms}}
+        <div class="chart-container">
+            <div class="chart-title">📶 Latency Histogram by Endpoint</div>
+            <div class="chart-subtitle">Log-scale buckets, share of each endpoint's requests per bucket</div>
+            <div class="chart-grid">
+                {{range $i, $h := .Histograms}}
+                <div>
+                    <div class="chart-subtitle"><code>{{$h.Endpoint}}</code> ({{$h.Count}} requests)</div>
+                    <div class="chart-wrapper">
+                        <canvas id="histogramChart{{$i}}"></canvas>
+                    </div>
+                </div>
+                {{end}}
+            </div>
+        </div>
+        {{end}}
+
+        {{if .Heatmaps}}
+        <div class="chart-container">
+            <div class="chart-title">🔥 Latency Heatmap</div>
+            <div class="heatmap-controls">
+                <label for="heatmapSelect">Endpoint:</label>
+                <select id="heatmapSelect">
+                    {{range $i, $h := .Heatmaps}}<option value="{{$i}}">{{$h.Name}}</option>{{end}}
+                </select>
+            </div>
+            <div class="heatmap-wrapper">
+                <canvas id="heatmapCanvas"></canvas>
+            </div>
+        </div>
+        {{end}}
+
         <div class="table-container">
             <div class="chart-title">🎯 Performance by Scenario</div>
             <table>
@@ -449,6 +239,302 @@ const htmlTemplate = `
             </table>
         </div>
 
+        <div class="table-container">
+            <div class="chart-title">🎯 Service Level Objectives</div>
+            <table>
+                <thead>
+                    <tr>
+                        <th>Endpoint</th>
+                        <th>Target T (ms)</th>
+                        <th>Objective</th>
+                        <th>Apdex</th>
+                        <th>Compliance</th>
+                        <th>Error Budget Left</th>
+                        <th>Max Burn Rate</th>
+                        <th>Status</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{range .SLOList}}
+                    <tr>
+                        <td><code>{{.Name}}</code></td>
+                        <td>{{printf "%.0f" .LatencyTargetMs}}</td>
+                        <td>{{printf "%.2f" .SuccessObjective}}%</td>
+                        <td>{{printf "%.2f" .Apdex}} <span style="color: #999;">({{.Rating}})</span></td>
+                        <td>{{printf "%.2f" .Compliance}}%</td>
+                        <td>{{printf "%.0f" .BudgetRemaining}}%</td>
+                        <td>{{printf "%.1f" .MaxBurnRate}}×</td>
+                        <td>
+                            <span class="badge {{if .Met}}badge-success{{else}}badge-danger{{end}}">{{if .Met}}Met{{else}}Missed{{end}}</span>
+                        </td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </div>
+
+        {{if .BurnRateChart}}
+        <div class="chart-container">
+            <div class="chart-title">🔥 Error Budget Burn Rate</div>
+            <div class="chart-subtitle">Burn rate per SLO window; 1× consumes the budget exactly over the run, above 1× exhausts it early</div>
+            <div class="chart-wrapper">
+                <canvas id="burnRateChart"></canvas>
+            </div>
+        </div>
+        {{end}}
+
+        {{if and .Metadata .Metadata.Capacity}}
+        {{with .Metadata.Capacity}}
+        <div class="table-container">
+            <div class="chart-title">🚀 Capacity Search</div>
+            <div class="chart-subtitle">
+                {{if gt .MaxSustainableRate 0.0}}Max sustainable rate <strong>{{printf "%.1f" .MaxSustainableRate}} it/s</strong>{{else}}No tested rate met the SLO{{end}}
+                {{if gt .KneeRate 0.0}} · latency knee at ~{{printf "%.1f" .KneeRate}} it/s{{end}}
+                · {{.Strategy}} strategy, {{len .Steps}} steps
+            </div>
+            <table>
+                <thead>
+                    <tr>
+                        <th>Target (it/s)</th>
+                        <th>Achieved (it/s)</th>
+                        <th>Throughput (req/s)</th>
+                        <th>Success</th>
+                        <th>Median (ms)</th>
+                        <th>P95 (ms)</th>
+                        <th>P99 (ms)</th>
+                        <th>Apdex</th>
+                        <th>Dropped</th>
+                        <th>Result</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{range .Steps}}
+                    <tr>
+                        <td>{{printf "%.1f" .TargetRate}}</td>
+                        <td>{{printf "%.1f" .AchievedRate}}</td>
+                        <td>{{printf "%.1f" .Throughput}}</td>
+                        <td>{{printf "%.2f" .SuccessRate}}%</td>
+                        <td>{{printf "%.1f" .MedianDuration}}</td>
+                        <td>{{printf "%.1f" .P95Duration}}</td>
+                        <td>{{printf "%.1f" .P99Duration}}</td>
+                        <td>{{printf "%.2f" .Apdex}}</td>
+                        <td>{{.DroppedIterations}}</td>
+                        <td>
+                            <span class="badge {{if .Passed}}badge-success{{else}}badge-danger{{end}}">{{if .Passed}}Passed{{else}}Failed{{end}}</span>
+                            {{if .Reason}}<span style="color: #999;">{{.Reason}}</span>{{end}}
+                        </td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </div>
+        {{end}}
+
+        <div class="chart-container">
+            <div class="chart-title">📐 Latency vs Arrival Rate</div>
+            <div class="chart-subtitle">Tail latency per capacity step (left axis) against achieved throughput (right axis)</div>
+            <div class="chart-wrapper">
+                <canvas id="capacityChart"></canvas>
+            </div>
+        </div>
+        {{end}}
+
+        {{if and .Metadata .Metadata.Soak}}
+        {{with .Metadata.Soak}}
+        <div class="table-container">
+            <div class="chart-title">🕰️ Soak Trends</div>
+            <div class="chart-subtitle">
+                {{if .Drifting}}<span class="badge badge-danger">Drift detected</span>{{else}}<span class="badge badge-success">Stable</span>{{end}}
+                Linear fit over {{printf "%.0f" .WindowSeconds}}s windows advancing every {{printf "%.0f" .StepSeconds}}s; drift means a significant (95%) trend beyond {{printf "%.0f" .DriftThreshold}}%/h latency or memory growth, throughput decay, or {{printf "%.1f" .ErrorDriftThreshold}} pp/h error-rate growth
+            </div>
+            <table>
+                <thead>
+                    <tr>
+                        <th>Series</th>
+                        <th>Metric</th>
+                        <th>Windows</th>
+                        <th>Baseline</th>
+                        <th>Trend</th>
+                        <th>R²</th>
+                        <th>t</th>
+                        <th>Status</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{range $.SoakSeries}}
+                    {{$name := .Name}}
+                    {{range .Trends}}
+                    <tr>
+                        <td><code>{{$name}}</code></td>
+                        <td>{{.Metric}}</td>
+                        <td>{{.Samples}}</td>
+                        <td>{{printf "%.2f" .Baseline}}</td>
+                        <td>{{if eq .Metric "error_rate"}}{{printf "%+.2f" .SlopePerHour}} pp/h{{else}}{{printf "%+.1f" .ChangePerHour}}%/h{{end}}</td>
+                        <td>{{printf "%.2f" .RSquared}}</td>
+                        <td>{{printf "%.1f" .TStat}}</td>
+                        <td>
+                            <span class="badge {{if .Drifting}}badge-danger{{else if .Significant}}badge-warning{{else}}badge-success{{end}}">{{if .Drifting}}Drifting{{else if .Significant}}Trend{{else}}Flat{{end}}</span>
+                        </td>
+                    </tr>
+                    {{end}}
+                    {{end}}
+                    {{range .ResourceTrends}}
+                    <tr>
+                        <td><code>resources</code></td>
+                        <td>{{.Metric}}</td>
+                        <td>{{.Samples}}</td>
+                        <td>{{printf "%.2f" .Baseline}}</td>
+                        <td>{{printf "%+.1f" .ChangePerHour}}%/h</td>
+                        <td>{{printf "%.2f" .RSquared}}</td>
+                        <td>{{printf "%.1f" .TStat}}</td>
+                        <td>
+                            <span class="badge {{if .Drifting}}badge-danger{{else if .Significant}}badge-warning{{else}}badge-success{{end}}">{{if .Drifting}}Drifting{{else if .Significant}}Trend{{else}}Flat{{end}}</span>
+                        </td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </div>
+        {{end}}
+
+        <div class="chart-grid">
+            <div class="chart-container">
+                <div class="chart-title">📈 P95 per Soak Window</div>
+                <div class="chart-wrapper">
+                    <canvas id="soakLatencyChart"></canvas>
+                </div>
+            </div>
+            <div class="chart-container">
+                <div class="chart-title">⚠️ Error Rate per Soak Window</div>
+                <div class="chart-wrapper">
+                    <canvas id="soakErrorChart"></canvas>
+                </div>
+            </div>
+        </div>
+
+        {{if .ResourceChart}}
+        <div class="chart-container">
+            <div class="chart-title">📡 Backend Resources</div>
+            <div class="chart-subtitle">MongoDB resident memory (left axis) and /health latency (right axis) sampled during the run</div>
+            <div class="chart-wrapper">
+                <canvas id="resourceChart"></canvas>
+            </div>
+        </div>
+        {{end}}
+        {{end}}
+
+        {{if and .Metadata .Metadata.Workers}}
+        <div class="table-container">
+            <div class="chart-title">🛰️ Workers</div>
+            <div class="chart-subtitle">Distributed run merged from {{len .Metadata.Workers}} workers' streamed histograms; figures are aggregated from latency buckets, so per-request charts are empty</div>
+            <table>
+                <thead>
+                    <tr>
+                        <th>Worker</th>
+                        <th>Name</th>
+                        <th>Requests</th>
+                        <th>Reports</th>
+                        <th>Final Report</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{range .Metadata.Workers}}
+                    <tr>
+                        <td><code>{{.ID}}</code></td>
+                        <td>{{.Name}}</td>
+                        <td>{{.Requests}}</td>
+                        <td>{{.Reports}}</td>
+                        <td>{{if .Final}}✅{{else}}⚠️ missing{{end}}</td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </div>
+        {{end}}
+
+        {{if .FaultBands}}
+        <div class="table-container">
+            <div class="chart-title">🌪️ Injected Faults</div>
+            <div class="chart-subtitle">Chaos proxy phases against {{.FaultLog.Target}}, shaded on the time-series charts</div>
+            <table>
+                <thead>
+                    <tr>
+                        <th>Phase</th>
+                        <th>Window</th>
+                        <th>Injected</th>
+                        <th>Faults</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{range .FaultBands}}
+                    <tr>
+                        <td>{{.Name}}</td>
+                        <td>{{.Start}} – {{.End}}</td>
+                        <td>{{.Injected}}</td>
+                        <td>{{.Summary}}</td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </div>
+        {{end}}
+
+        <div class="table-container">
+            <div class="chart-title">🧾 Status Codes by Endpoint</div>
+            <table>
+                <thead>
+                    <tr>
+                        <th>Endpoint</th>
+                        <th>Status Codes</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    <tr>
+                        <td><strong>All endpoints</strong></td>
+                        <td>{{range .StatusCodes}}<span class="badge {{statusBadge .Code}}">{{if eq .Code 0}}no response{{else}}{{.Code}}{{end}} × {{.Count}}</span> {{end}}</td>
+                    </tr>
+                    {{range .EndpointList}}
+                    <tr>
+                        <td><code>{{.Endpoint}}</code></td>
+                        <td>{{range .StatusCodes}}<span class="badge {{statusBadge .Code}}">{{if eq .Code 0}}no response{{else}}{{.Code}}{{end}} × {{.Count}}</span> {{end}}</td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </div>
+
+        {{if .ErrorTaxonomy}}
+        <div class="table-container">
+            <div class="chart-title">🩺 Error Taxonomy</div>
+            <table>
+                <thead>
+                    <tr>
+                        <th>Category</th>
+                        <th>Count</th>
+                        <th>Percentage</th>
+                        <th>Sample Responses</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{range .ErrorTaxonomy}}
+                    <tr>
+                        <td><code>{{.Category}}</code></td>
+                        <td>{{.Count}}</td>
+                        <td>
+                            <span class="badge badge-danger">
+                                {{printf "%.2f" (mulf (divf (float64 .Count) (float64 $.Stats.TotalRequests)) 100.0)}}%
+                            </span>
+                        </td>
+                        <td>
+                            {{range .Samples}}<pre class="response-sample">{{.}}</pre>{{else}}<span style="color: #999;">—</span>{{end}}
+                        </td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </div>
+        {{end}}
+
         {{if .ErrorList}}
         <div class="table-container">
             <div class="chart-title">⚠️ Error Analysis</div>
@@ -492,9 +578,12 @@ const htmlTemplate = `
             success: '#10b981',
             warning: '#f59e0b',
             danger: '#ef4444',
-            grid: '#e9ecef'
+            grid: '#e9ecef',
+            palette: ['#667eea', '#764ba2', '#10b981', '#f59e0b', '#ef4444', '#3b82f6', '#ec4899', '#14b8a6', '#8b5cf6', '#84cc16']
         };
 
+        const faultBands = {{toJSON .FaultBands}} || [];
+
         const commonOptions = {
             responsive: true,
             maintainAspectRatio: false,
@@ -532,7 +621,8 @@ const htmlTemplate = `
                     tension: 0.4
                 }]
             },
-            options: commonOptions
+            options: commonOptions,
+            plugins: [faultBandsPlugin(faultBands, 'rgba(239,68,68,0.12)', '#b91c1c')]
         });
 
         new Chart(document.getElementById('responseTimeChart'), {
@@ -548,7 +638,8 @@ const htmlTemplate = `
                     tension: 0.4
                 }]
             },
-            options: commonOptions
+            options: commonOptions,
+            plugins: [faultBandsPlugin(faultBands, 'rgba(239,68,68,0.12)', '#b91c1c')]
         });
 
         new Chart(document.getElementById('percentileChart'), {
@@ -576,6 +667,221 @@ const htmlTemplate = `
                 }
             }
         });
+
+        new Chart(document.getElementById('spectrumChart'), {
+            type: 'line',
+            data: {
+                labels: {{toJSON .Spectrum.Labels}},
+                datasets: {{toJSON .Spectrum.Series}}.map((series, i) => ({
+                    label: series.Name,
+                    data: series.Values,
+                    borderColor: i === 0 ? chartColors.primary : chartColors.palette[i % chartColors.palette.length],
+                    borderWidth: i === 0 ? 3 : 1.5,
+                    hidden: i > 5,
+                    fill: false,
+                    tension: 0.2
+                }))
+            },
+            options: {
+                ...commonOptions,
+                scales: {
+                    ...commonOptions.scales,
+                    y: {
+                        type: 'logarithmic',
+                        title: { display: true, text: 'Response Time (ms)' },
+                        grid: { color: chartColors.grid }
+                    }
+                }
+            }
+        });
+
+        {{if .Histograms}}
+        {{toJSON .Histograms}}.forEach((histogram, i) => {
+            new Chart(document.getElementById('histogramChart' + i), {
+                type: 'bar',
+                data: {
+                    labels: histogram.Labels,
+                    datasets: [{
+                        label: '% of requests',
+                        data: histogram.Percent,
+                        backgroundColor: chartColors.primary,
+                        barPercentage: 1.0,
+                        categoryPercentage: 1.0
+                    }]
+                },
+                options: {
+                    ...commonOptions,
+                    plugins: {
+                        legend: {
+                            display: false
+                        }
+                    }
+                }
+            });
+        });
+        {{end}}
+
+        {{if .BurnRateChart}}
+        new Chart(document.getElementById('burnRateChart'), {
+            type: 'line',
+            data: {
+                datasets: {{toJSON .BurnRateChart}}.map((series, i) => ({
+                    label: series.Name,
+                    data: series.Points,
+                    borderColor: i === 0 ? chartColors.danger : chartColors.palette[i % chartColors.palette.length],
+                    borderWidth: i === 0 ? 3 : 1.5,
+                    hidden: i > 5,
+                    fill: false,
+                    tension: 0.2
+                }))
+            },
+            options: {
+                ...commonOptions,
+                scales: {
+                    ...commonOptions.scales,
+                    x: {
+                        type: 'linear',
+                        title: { display: true, text: 'Elapsed (min)' },
+                        grid: { color: chartColors.grid }
+                    }
+                }
+            }
+        });
+        {{end}}
+
+        const capacitySeries = {{toJSON .CapacityChart}};
+
+        if (capacitySeries && capacitySeries.length > 0) {
+            new Chart(document.getElementById('capacityChart'), {
+                type: 'line',
+                data: {
+                    datasets: capacitySeries.map((series, i) => ({
+                        label: series.Name,
+                        data: series.Points,
+                        yAxisID: i === 2 ? 'y1' : 'y',
+                        borderColor: [chartColors.warning, chartColors.danger, chartColors.primary][i],
+                        borderDash: i === 2 ? [6, 4] : undefined,
+                        borderWidth: 2,
+                        pointRadius: 4,
+                        fill: false,
+                        tension: 0.2
+                    }))
+                },
+                options: {
+                    ...commonOptions,
+                    scales: {
+                        x: {
+                            type: 'linear',
+                            title: { display: true, text: 'Target rate (it/s)' },
+                            grid: { color: chartColors.grid }
+                        },
+                        y: {
+                            beginAtZero: true,
+                            title: { display: true, text: 'Latency (ms)' },
+                            grid: { color: chartColors.grid }
+                        },
+                        y1: {
+                            beginAtZero: true,
+                            position: 'right',
+                            title: { display: true, text: 'Throughput (req/s)' },
+                            grid: { drawOnChartArea: false }
+                        }
+                    }
+                }
+            });
+        }
+
+        const soakCharts = [
+            ['soakLatencyChart', {{toJSON .SoakLatency}}, 'P95 (ms)'],
+            ['soakErrorChart', {{toJSON .SoakErrors}}, 'Error rate (%)']
+        ];
+
+        soakCharts.forEach(([id, seriesList, axisLabel]) => {
+            if (!seriesList || seriesList.length === 0) {
+                return;
+            }
+            new Chart(document.getElementById(id), {
+                type: 'line',
+                data: {
+                    datasets: seriesList.map((series, i) => ({
+                        label: series.Name,
+                        data: series.Points,
+                        borderColor: i === 0 ? chartColors.primary : chartColors.palette[i % chartColors.palette.length],
+                        borderWidth: i === 0 ? 3 : 1.5,
+                        hidden: i > 5,
+                        fill: false,
+                        tension: 0.2
+                    }))
+                },
+                options: {
+                    ...commonOptions,
+                    scales: {
+                        x: {
+                            type: 'linear',
+                            title: { display: true, text: 'Elapsed (min)' },
+                            grid: { color: chartColors.grid }
+                        },
+                        y: {
+                            beginAtZero: true,
+                            title: { display: true, text: axisLabel },
+                            grid: { color: chartColors.grid }
+                        }
+                    }
+                }
+            });
+        });
+
+        const resourceSeries = {{toJSON .ResourceChart}};
+
+        if (resourceSeries && resourceSeries.length > 0) {
+            new Chart(document.getElementById('resourceChart'), {
+                type: 'line',
+                data: {
+                    datasets: resourceSeries.map((series, i) => ({
+                        label: series.Name,
+                        data: series.Points,
+                        yAxisID: i === 1 ? 'y1' : 'y',
+                        borderColor: i === 0 ? chartColors.secondary : chartColors.warning,
+                        borderWidth: 2,
+                        pointRadius: 2,
+                        fill: false,
+                        tension: 0.2
+                    }))
+                },
+                options: {
+                    ...commonOptions,
+                    scales: {
+                        x: {
+                            type: 'linear',
+                            title: { display: true, text: 'Elapsed (min)' },
+                            grid: { color: chartColors.grid }
+                        },
+                        y: {
+                            beginAtZero: true,
+                            title: { display: true, text: 'Resident memory (MB)' },
+                            grid: { color: chartColors.grid }
+                        },
+                        y1: {
+                            beginAtZero: true,
+                            position: 'right',
+                            title: { display: true, text: '/health latency (ms)' },
+                            grid: { drawOnChartArea: false }
+                        }
+                    }
+                }
+            });
+        }
+
+        const heatmaps = {{toJSON .Heatmaps}};
+
+        if (heatmaps && heatmaps.length > 0) {
+            const heatmapCanvas = document.getElementById('heatmapCanvas');
+            const heatmapSelect = document.getElementById('heatmapSelect');
+            const renderHeatmap = () => drawHeatmap(heatmapCanvas, heatmaps[heatmapSelect.value], chartColors.grid);
+            heatmapSelect.addEventListener('change', renderHeatmap);
+            window.addEventListener('resize', renderHeatmap);
+            renderHeatmap();
+        }
     </script>
 </body>
 </html>
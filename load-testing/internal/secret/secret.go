@@ -0,0 +1,185 @@
+package secret
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const mask = "***"
+
+type Value string
+
+func (v Value) Reveal() string {
+	return string(v)
+}
+
+func (v Value) String() string {
+	if v == "" {
+		return ""
+	}
+	return mask
+}
+
+func (v Value) GoString() string {
+	return fmt.Sprintf("secret.Value(%q)", v.String())
+}
+
+func (v Value) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+var envRef = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+func Resolve(raw string) (string, error) {
+	if name, ok := strings.CutPrefix(raw, "env:"); ok {
+		value := os.Getenv(name)
+		if value == "" {
+			return "", fmt.Errorf("env:%s is not set", name)
+		}
+		return value, nil
+	}
+
+	if path, ok := strings.CutPrefix(raw, "file:"); ok {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret file: %w", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	var missing []string
+	resolved := envRef.ReplaceAllStringFunc(raw, func(ref string) string {
+		name := envRef.FindStringSubmatch(ref)[1]
+		value := os.Getenv(name)
+		if value == "" {
+			missing = append(missing, name)
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("referenced env vars not set: %s", strings.Join(missing, ", "))
+	}
+	return resolved, nil
+}
+
+type uriParts struct {
+	scheme, userinfo, hosts, rest string
+}
+
+func splitURI(uri string) (uriParts, bool) {
+	scheme, remainder, ok := strings.Cut(uri, "://")
+	if !ok {
+		return uriParts{}, false
+	}
+	parts := uriParts{scheme: scheme}
+
+	authority := remainder
+	if i := strings.IndexAny(remainder, "/?"); i >= 0 {
+		authority, parts.rest = remainder[:i], remainder[i:]
+	}
+	if i := strings.LastIndex(authority, "@"); i >= 0 {
+		parts.userinfo, parts.hosts = authority[:i], authority[i+1:]
+	} else {
+		parts.hosts = authority
+	}
+	return parts, true
+}
+
+func (p uriParts) String() string {
+	uri := p.scheme + "://"
+	if p.userinfo != "" {
+		uri += p.userinfo + "@"
+	}
+	return uri + p.hosts + p.rest
+}
+
+func SplitPassword(uri string) (string, string) {
+	parts, ok := splitURI(uri)
+	if !ok {
+		return uri, ""
+	}
+	username, password, hasPassword := strings.Cut(parts.userinfo, ":")
+	if !hasPassword {
+		return uri, ""
+	}
+	if unescaped, err := url.PathUnescape(password); err == nil {
+		password = unescaped
+	}
+	parts.userinfo = username
+	return parts.String(), password
+}
+
+func WithCredentials(uri, username string, password Value) string {
+	parts, ok := splitURI(uri)
+	if !ok {
+		return uri
+	}
+	if username == "" {
+		username, _, _ = strings.Cut(parts.userinfo, ":")
+		if unescaped, err := url.PathUnescape(username); err == nil {
+			username = unescaped
+		}
+	}
+	if username == "" {
+		return uri
+	}
+
+	if password == "" {
+		parts.userinfo = url.User(username).String()
+	} else {
+		parts.userinfo = url.UserPassword(username, password.Reveal()).String()
+	}
+	return parts.String()
+}
+
+func MaskURI(uri string) string {
+	parts, ok := splitURI(uri)
+	if !ok {
+		return Redact(uri)
+	}
+	if username, _, hasPassword := strings.Cut(parts.userinfo, ":"); hasPassword {
+		parts.userinfo = username + ":" + mask
+	}
+
+	if path, query, hasQuery := strings.Cut(parts.rest, "?"); hasQuery {
+		options := strings.Split(query, "&")
+		for i, option := range options {
+			key, _, hasValue := strings.Cut(option, "=")
+			if hasValue && sensitiveOption(key) {
+				options[i] = key + "=" + mask
+			}
+		}
+		parts.rest = path + "?" + strings.Join(options, "&")
+	}
+	return parts.String()
+}
+
+func sensitiveOption(key string) bool {
+	key = strings.ToLower(key)
+	return strings.Contains(key, "password") || strings.Contains(key, "secret") ||
+		strings.Contains(key, "token") || key == "authmechanismproperties"
+}
+
+var redactions = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`([A-Za-z][A-Za-z0-9+.-]*://[^:/@\s"]*:)[^@\s/"]+@`), "${1}" + mask + "@"},
+	{regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9\-._~+/]+=*`), "${1}" + mask},
+	{regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`), mask},
+	{regexp.MustCompile(`(?i)(\\?"(?:password|token|secret|authorization|api_?key|access_?token|refresh_?token)\\?"\s*:\s*\\?")[^"\\]*`), "${1}" + mask},
+	{regexp.MustCompile(`(?i)([?&](?:password|token|secret|api_?key|access_?token)=)[^&\s"]+`), "${1}" + mask},
+}
+
+func Redact(text string) string {
+	if text == "" {
+		return text
+	}
+	for _, r := range redactions {
+		text = r.pattern.ReplaceAllString(text, r.replacement)
+	}
+	return text
+}